@@ -2,7 +2,10 @@ package aver
 
 import (
 	"database/sql"
+	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -14,8 +17,506 @@ func (e AverError) Error() string {
 	return "aver: " + e.Msg
 }
 
-// checks values against a validation string
-func Holds(validation string, db *sql.DB, tbl string) (b bool, err error) {
+// Result is the outcome of evaluating a validation statement
+type Result struct {
+	// Holds is true if the expectation holds for every point. For statements
+	// with a 'for each' clause, it is true only if it holds for every group;
+	// for all-pairs and 'rank' statements, only if it holds for every pair
+	Holds bool
+
+	// Groups contains the verdict for each distinct combination of values of
+	// the columns given in a 'for each' clause
+	Groups []GroupResult
+
+	// Pairs contains the verdict for each of the comparisons that an
+	// all-pairs ('*other*') or 'rank' statement expands to
+	Pairs []PairResult
+
+	// Statistics contains, for variability statements (e.g.
+	// 'cv(throughput) < 0.05'), the value of the statistic for each
+	// configuration group; Worst points to the group that is the furthest from
+	// satisfying the statement
+	Statistics []GroupStatistic
+	Worst      *GroupStatistic
+
+	// Distributions contains the outcome of distribution statements (e.g.
+	// 'latency(method='a') dominates latency(method='b')'), one per group or
+	// pair
+	Distributions []DistributionResult
+
+	// Correlations contains the outcome of correlation statements (e.g.
+	// 'corr(throughput, size) > 0.9'), one per group
+	Correlations []CorrelationResult
+
+	// Points contains the verdict for each of the points of a statement that
+	// is evaluated point by point, such as 'speedup(throughput, size) >= 0.8
+	// * size' or log-scale comparisons ('... by 10x')
+	Points []PointResult
+
+	// Ranges contains the range of ratios observed for ratio statements
+	// (e.g. 'throughput(method='a') / throughput(method='b') between 1.8 and
+	// 2.2'), one per group or pair
+	Ranges []RangeResult
+
+	// Dominations contains, for Pareto statements, every configuration that
+	// is expected to be Pareto-optimal but is dominated, along with the one
+	// dominating it
+	Dominations []Domination
+
+	// Outliers contains the rows left out by an 'excluding outliers' clause
+	Outliers []Outlier
+
+	// Counterexamples contains, for comparisons, the points for which they
+	// don't hold, along with the value they're compared against (Bound)
+	Counterexamples []PointResult
+
+	// Queries contains, for comparisons on a table of a database, the SQL
+	// query that Holds runs to check each of them. Other datasets are checked
+	// outside of a database (see Dataset), so there are no queries for them.
+	Queries []string
+
+	// Missing is the number of rows with missing values in the columns the
+	// statement refers to, which are treated according to the MissingPolicy
+	Missing int
+}
+
+// GroupResult is the verdict for one of the groups of a 'for each' clause
+type GroupResult struct {
+	// Group is the conjunction of predicates that selects the rows of the
+	// group, e.g. "workload='read' and replication=3"
+	Group string
+	Holds bool
+}
+
+// PairResult is the verdict for one of the comparisons of an all-pairs or
+// 'rank' statement
+type PairResult struct {
+	// Group is the 'for each' group the pair belongs to (empty if the
+	// statement has no 'for each' clause)
+	Group string
+	// Left and Right are the predicates on each side of the comparison, e.g.
+	// "method='a'" and "method='b'"
+	Left  string
+	Right string
+	Holds bool
+}
+
+// checks values against a validation string. Placeholders in the statement
+// are bound to the values given in params
+func Holds(validation string, db *sql.DB, tbl string, params ...Params) (b bool, err error) {
+	r, err := Evaluate(validation, db, tbl, params...)
+	if err != nil {
+		return
+	}
+	return r.Holds, nil
+}
+
+// Options holds the settings that affect how a statement is evaluated
+type Options struct {
+	// Params holds the values bound to the placeholders of the statement
+	Params Params
+	// Missing is the policy for rows with missing values (MissingFail if not
+	// given). An 'on missing' clause in the statement takes precedence.
+	Missing MissingPolicy
+}
+
+// Evaluate checks values against a validation string, like Holds does, but
+// also reports the verdict for each of the groups of a 'for each' clause and
+// for each of the pairs of an all-pairs or 'rank' statement
+func Evaluate(validation string, db *sql.DB, tbl string, params ...Params) (r Result, err error) {
+	return EvaluateWithOptions(validation, db, tbl, Options{Params: MergeParams(params...)})
+}
+
+// EvaluateWithOptions is like Evaluate but takes the settings for the
+// evaluation as Options
+func EvaluateWithOptions(validation string, db *sql.DB, tbl string, opts Options) (
+	r Result, err error) {
+
+	if db == nil {
+		return r, AverError{"null sql.DB pointer"}
+	}
+	return EvaluateDataset(validation, NewSQLDataset(db, tbl), opts)
+}
+
+// EvaluateDataset is like EvaluateWithOptions but evaluates the statement
+// against any Dataset, e.g. one held in memory
+func EvaluateDataset(validation string, ds Dataset, opts Options) (r Result, err error) {
+	v, err := ParseValidation(validation)
+	if err != nil {
+		return
+	}
+	return v.Evaluate(ds, opts)
+}
+
+// Evaluate checks a parsed statement (e.g. one of those returned by
+// ParseValidationFile) against a Dataset
+func (v Validation) Evaluate(ds Dataset, opts Options) (r Result, err error) {
+	if ds == nil {
+		return r, AverError{"null dataset"}
+	}
+	if v, err = v.bind(opts.Params); err != nil {
+		return
+	}
+
+	policy := opts.Missing
+	if v.missing != "" {
+		policy = MissingPolicy(v.missing)
+	}
+	if policy == "" {
+		policy = MissingFail
+	}
+	if !policy.valid() {
+		return r, AverError{"unknown policy for missing values '" + string(policy) + "'"}
+	}
+
+	if v.outliers != "" && (v.scaling != "" || v.correlation != "" ||
+		v.distribution != "" || len(v.objectives) > 0) {
+		return r, AverError{"excluding outliers is only supported for comparisons, " +
+			"ratios and variability statements"}
+	}
+
+	if v.matchColumn != "" && !v.isComparison() && v.lower == "" &&
+		v.factor == "" && v.orders == "" {
+		return r, AverError{"'matching' and 'interpolate' are only supported for " +
+			"comparisons, ratios and log-scale comparisons"}
+	}
+
+	// malformed comparisons are reported before going to the database
+	if v.isComparison() {
+		if _, err = v.checkComparison(); err != nil {
+			return
+		}
+	}
+
+	groups := [][]predicate{nil}
+	if len(v.groupBy) > 0 {
+		if groups, err = distinctValues(ds, v.groupBy, v.globalTerms); err != nil {
+			return
+		}
+		if len(groups) == 0 {
+			return r, AverError{"no values associated to 'for each' columns"}
+		}
+	}
+
+	// the expectation is evaluated on each group by adding the predicates that
+	// select the group to the global ones
+	r.Holds = true
+	for _, group := range groups {
+		gv := v
+		gv.globalTerms = append(append([]predicate{}, v.globalTerms...), group...)
+		gv.global = conjunction(gv.globalTerms)
+
+		comparisons, err := gv.expand(ds)
+		if err != nil {
+			return r, inGroup(err, group)
+		}
+
+		missing, err := countMissing(ds, gv.globalTerms, comparisons)
+		if err != nil {
+			return r, inGroup(err, group)
+		}
+		r.Missing += missing
+		if missing > 0 && policy == MissingError {
+			return r, inGroup(missingError(missing), group)
+		}
+
+		groupHolds := !(missing > 0 && policy == MissingFail)
+		for _, c := range comparisons {
+			var holds bool
+			var outliers []Outlier
+			if c.statistic != "" {
+				var stats []GroupStatistic
+				holds, stats, outliers, err = c.variability(ds)
+				r.Statistics = append(r.Statistics, stats...)
+			} else if c.scaling != "" {
+				var points []PointResult
+				holds, points, err = c.scale(ds)
+				for i := range points {
+					points[i].Group = conjunction(group)
+				}
+				r.Points = append(r.Points, points...)
+			} else if len(c.objectives) > 0 {
+				var dominations []Domination
+				holds, dominations, err = c.pareto(ds)
+				for i := range dominations {
+					dominations[i].Group = conjunction(group)
+				}
+				r.Dominations = append(r.Dominations, dominations...)
+			} else if c.factor != "" || c.orders != "" {
+				var points []PointResult
+				holds, points, outliers, err = c.logScale(ds)
+				for i := range points {
+					points[i].Group = conjunction(group)
+				}
+				r.Points = append(r.Points, points...)
+			} else if c.lower != "" {
+				var rr RangeResult
+				rr, outliers, err = c.ratioRange(ds)
+				rr.Group = conjunction(group)
+				holds = rr.Holds
+				if err == nil {
+					r.Ranges = append(r.Ranges, rr)
+				}
+			} else if c.correlation != "" {
+				var cr CorrelationResult
+				cr, err = c.correlate(ds)
+				cr.Group = conjunction(group)
+				holds = cr.Holds
+				if err == nil {
+					r.Correlations = append(r.Correlations, cr)
+				}
+			} else if c.distribution != "" {
+				var d DistributionResult
+				d, err = c.compareDistributions(ds)
+				d.Group = conjunction(group)
+				holds = d.Holds
+				if err == nil {
+					r.Distributions = append(r.Distributions, d)
+				}
+			} else {
+				var counterexamples []PointResult
+				if sd, ok := ds.(*sqlDataset); ok && len(sd.where) == 0 &&
+					c.outliers == "" && c.matchColumn == "" && missing == 0 {
+					holds, err = c.holds(sd)
+					// the points for which it doesn't hold are only looked for
+					// (outside of the database) when it fails
+					if err == nil && !holds {
+						_, counterexamples, _, err = c.comparePoints(ds)
+					}
+				} else {
+					holds, counterexamples, outliers, err = c.comparePoints(ds)
+				}
+				var query string
+				var hasQuery bool
+				if err == nil {
+					query, hasQuery, err = c.queryOn(ds)
+				}
+				for i := range counterexamples {
+					counterexamples[i].Group = conjunction(group)
+				}
+				r.Counterexamples = append(r.Counterexamples, counterexamples...)
+				if hasQuery {
+					r.Queries = append(r.Queries, query)
+				}
+			}
+			if err != nil {
+				if gv.isPairwise() {
+					err = inPair(err, c)
+				}
+				return r, inGroup(err, group)
+			}
+			r.addOutliers(outliers, group)
+			if gv.isPairwise() {
+				r.Pairs = append(r.Pairs, PairResult{
+					conjunction(group), c.left.predicates, c.right.predicates, holds})
+			}
+			groupHolds = groupHolds && holds
+		}
+
+		if len(group) > 0 {
+			r.Groups = append(r.Groups, GroupResult{conjunction(group), groupHolds})
+		}
+		r.Holds = r.Holds && groupHolds
+	}
+
+	if len(r.Statistics) > 0 {
+		threshold, _ := strconv.ParseFloat(v.right.funcName, 64)
+		worst := worstGroup(r.Statistics, v.op, threshold)
+		r.Worst = &worst
+	}
+	return
+}
+
+// records the outliers excluded for a comparison, skipping those that were
+// already excluded for another comparison (e.g. the left side of every pair
+// of an all-pairs statement)
+func (r *Result) addOutliers(outliers []Outlier, group []predicate) {
+	previous := r.Outliers
+	for _, o := range outliers {
+		o.Group = conjunction(group)
+		seen := false
+		for _, existing := range previous {
+			seen = seen || existing == o
+		}
+		if !seen {
+			r.Outliers = append(r.Outliers, o)
+		}
+	}
+}
+
+// returns, as conjunctions of equality predicates, the distinct combinations
+// of values that the given columns take in the rows satisfying 'where',
+// ordered the way SQL does (numbers before strings). Combinations containing
+// NULL values are ignored.
+func distinctValues(ds Dataset, columns []string, where []predicate) (
+	combinations [][]predicate, err error) {
+
+	rows := make([][]interface{}, 0)
+	seen := make(map[string]bool)
+	err = filter(ds, where).Scan(columns, func(values []interface{}) error {
+		group := make([]predicate, len(columns))
+		for i, value := range values {
+			var ok bool
+			if group[i], ok = equalityPredicate(columns[i], value); !ok {
+				return nil
+			}
+		}
+		if key := conjunction(group); !seen[key] {
+			seen[key] = true
+			rows = append(rows, values)
+			combinations = append(combinations, group)
+		}
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	sort.Sort(byValues{rows, combinations})
+	return
+}
+
+// sorts combinations of values by the values they're made of
+type byValues struct {
+	values       [][]interface{}
+	combinations [][]predicate
+}
+
+func (s byValues) Len() int { return len(s.values) }
+
+func (s byValues) Swap(i, j int) {
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+	s.combinations[i], s.combinations[j] = s.combinations[j], s.combinations[i]
+}
+
+func (s byValues) Less(i, j int) bool {
+	for k := range s.values[i] {
+		if c := orderValues(s.values[i][k], s.values[j][k]); c != 0 {
+			return c < 0
+		}
+	}
+	return false
+}
+
+// builds a '<column>=<value>' predicate out of a value scanned from a row,
+// returning false for NULL values
+func equalityPredicate(column string, value interface{}) (p predicate, ok bool) {
+	p = predicate{column: column, op: "="}
+	switch v := value.(type) {
+	case nil:
+		return p, false
+	case int64:
+		p.literal = strconv.FormatInt(v, 10)
+	case float64:
+		p.literal = strconv.FormatFloat(v, 'f', -1, 64)
+	case []byte:
+		p.literal = strings.Replace(string(v), "'", "''", -1)
+		p.quoted = true
+	default:
+		p.literal = strings.Replace(fmt.Sprint(v), "'", "''", -1)
+		p.quoted = true
+	}
+	return p, true
+}
+
+// prefixes an error with the 'for each' group in which it occurred
+func inGroup(err error, group []predicate) error {
+	if len(group) == 0 {
+		return err
+	}
+	return prefixError(err, "for each "+conjunction(group))
+}
+
+// prefixes an error with the pair of an all-pairs or 'rank' statement in
+// which it occurred
+func inPair(err error, c Validation) error {
+	return prefixError(err, c.left.predicates+" vs. "+c.right.predicates)
+}
+
+func prefixError(err error, prefix string) error {
+	if e, ok := err.(AverError); ok {
+		return AverError{prefix + ": " + e.Msg}
+	}
+	return AverError{prefix + ": " + err.Error()}
+}
+
+// builds a WHERE clause out of the given (non-empty) conjunctions
+func whereClause(conjunctions ...string) string {
+	if c := and(conjunctions...); c != "" {
+		return " where " + c
+	}
+	return ""
+}
+
+// joins the given (non-empty) conjunctions into a single one
+func and(conjunctions ...string) string {
+	terms := make([]string, 0)
+	for _, c := range conjunctions {
+		if c != "" {
+			terms = append(terms, c)
+		}
+	}
+	return strings.Join(terms, " and ")
+}
+
+// obtains the name of the columns that identify a point, i.e. that both sides
+// of a comparison are joined on
+func (v Validation) joinColumns(ds Dataset) (columns []string, err error) {
+	c, err := ds.Schema()
+	if err != nil {
+		return
+	}
+
+	// from all column names, we remove columns appearing in predicates since
+	// those are the ones that we shouldn't be joining on (they'll likely have
+	// distinct values for left-side vs. right-side subsets), as well as
+	// metrics, which don't identify a point
+	metrics := metricsOf(ds)
+	columns = make([]string, 0)
+	for _, name := range c {
+		if name == v.left.funcName || metrics[strings.ToLower(name)] {
+			continue
+		}
+		if strings.Contains(v.left.predicates, name) {
+			continue
+		}
+		if strings.Contains(v.right.predicates, name) {
+			continue
+		}
+		columns = append(columns, name)
+	}
+	return
+}
+
+// whether a statement is a plain comparison between two sides (or a 'rank'
+// statement, which expands to those)
+func (v Validation) isComparison() bool {
+	return v.statistic == "" && v.scaling == "" && len(v.objectives) == 0 &&
+		v.factor == "" && v.orders == "" && v.lower == "" && v.correlation == "" &&
+		v.distribution == ""
+}
+
+// checks that a comparison refers to the same dependent variable on both
+// sides, or to a numeric literal on the right side
+func (v Validation) checkComparison() (isRightNumeric bool, err error) {
+	rxFloat := regexp.MustCompile("^[-+]?[0-9]?[\\.]?[0-9]+$")
+	isLeftNumeric := rxFloat.MatchString(v.left.funcName)
+	isRightNumeric = rxFloat.MatchString(v.right.funcName)
+	if isLeftNumeric && isRightNumeric {
+		return false, AverError{
+			"Expecting reference to a variable in comparison clause"}
+	} else if isLeftNumeric {
+		return false, AverError{
+			"Numeric is only supported on the RHS of comparison clause"}
+	} else if !isRightNumeric && v.left.funcName != v.right.funcName {
+		return false, AverError{
+			"Validation comparison; " + v.left.funcName + " distinct to " + v.right.funcName}
+	}
+	return
+}
+
+// checks whether a (parsed) validation holds
+func (v Validation) holds(ds *sqlDataset) (b bool, err error) {
 	// A validation statement can be seen as a very constrained subset of SQL:
 	//
 	//   * one relation
@@ -46,50 +547,22 @@ func Holds(validation string, db *sql.DB, tbl string) (b bool, err error) {
 	// evaluation of the comparison (`var(<left>) comp_op var(<right>)`), then the
 	// validation statement holds
 
-	if db == nil {
-		return false, AverError{"null sql.DB pointer"}
-	}
-
-	v, err := ParseValidation(validation)
-	if err != nil {
-		return
-	}
-
 	// we can only compare values from the same dependent variable (unless there's
 	// a numeric literal in the RHS)
 	// {
-	dependentVar := v.left.funcName
-	rxFloat := regexp.MustCompile("^[-+]?[0-9]?[\\.]?[0-9]+$")
-	isLeftNumeric := rxFloat.MatchString(v.left.funcName)
-	isRightNumeric := rxFloat.MatchString(v.right.funcName)
-	if isLeftNumeric && isRightNumeric {
-		return false, AverError{
-			"Expecting reference to a variable in comparison clause"}
-	} else if isLeftNumeric {
-		return false, AverError{
-			"Numeric is only supported on the RHS of comparison clause"}
-	} else if !isRightNumeric && v.left.funcName != v.right.funcName {
-		return false, AverError{
-			"Validation comparison; " + v.left.funcName + " distinct to " + v.right.funcName}
+	isRightNumeric, err := v.checkComparison()
+	if err != nil {
+		return
 	}
 	// }
 
 	// get predicates
 	// {
-	leftPredicates := v.left.predicates
-	rightPredicates := v.right.predicates
-	if v.global != "" {
-		leftPredicates = leftPredicates + " and " + v.global
-		rightPredicates = rightPredicates + " and " + v.global
-	}
-	if leftPredicates != "" {
-		leftPredicates = " where " + leftPredicates
-	}
-	if rightPredicates != "" {
-		rightPredicates = " where " + rightPredicates
-	}
+	leftPredicates := v.left.where(ds.dialect, v.globalTerms)
+	rightPredicates := v.right.where(ds.dialect, v.globalTerms)
 	// }
 
+	db, tbl := ds.db, ds.table
 	var countForLeft int
 	var countForRight int
 
@@ -120,44 +593,16 @@ func Holds(validation string, db *sql.DB, tbl string) (b bool, err error) {
 
 	// obtain the name of columns we want in the select list
 	// {
-	rows, err := db.Query("SELECT * FROM " + tbl + " LIMIT 1")
-	if err != nil {
-		return
-	}
-	c, err := rows.Columns()
+	columns, err := v.joinColumns(ds)
 	if err != nil {
 		return
 	}
-	rows.Close()
-
-	// from all column names, we remove columns appearing in predicates since
-	// those are the ones that we shouldn't be joining on (they'll likely have
-	// distinct values for left-side vs. right-side subsets)
-	columns := make([]string, 0)
-	for _, name := range c {
-		if name == dependentVar {
-			continue
-		}
-		if strings.Contains(v.left.predicates, name) {
-			continue
-		}
-		if strings.Contains(v.right.predicates, name) {
-			continue
-		}
-		columns = append(columns, name)
-	}
 	// }
 
 	// then we check to see that both left and right sides have the same values
 	// for columns not appearing in left/right predicates
 	var count int
-	err = db.QueryRow(
-		"select count(*) " +
-			"from ( " +
-			"   (select " + strings.Join(columns, ",") + " from " + tbl + leftPredicates + ")" +
-			"   natural join " +
-			"   (select " + strings.Join(columns, ",") + " from " + tbl + rightPredicates + ")" +
-			")").Scan(&count)
+	err = db.QueryRow(v.joinQuery(ds.dialect, tbl, columns)).Scan(&count)
 	if err != nil {
 		return
 	}
@@ -170,35 +615,75 @@ func Holds(validation string, db *sql.DB, tbl string) (b bool, err error) {
 	// above but we also get the column for the dependent variable and test the
 	// condition at the outermost WHERE clause
 	// {
+	err = db.QueryRow(v.query(ds.dialect, tbl, columns, isRightNumeric)).Scan(&count)
+	if err != nil {
+		return
+	}
+	if count != valueCount {
+		return false, nil
+	}
+	return true, nil
+	// }
+}
+
+// builds the WHERE clause that selects the rows of a side of a statement
+func (val Value) where(d Dialect, global []predicate) string {
+	return whereClause(sqlConjunction(d, val.terms), sqlConjunction(d, global))
+}
+
+// builds the query that counts the points that both sides of a comparison
+// have in common
+func (v Validation) joinQuery(d Dialect, tbl string, columns []string) string {
+	return "select count(*) from (" + d.Join(
+		"(select "+quoteList(d, columns)+" from "+tbl+v.left.where(d, v.globalTerms)+")",
+		"(select "+quoteList(d, columns)+" from "+tbl+v.right.where(d, v.globalTerms)+")",
+		columns) + ")"
+}
+
+// builds the query that counts the points for which a comparison holds. The
+// aliases of the dependent variable are reserved words, so they are quoted as
+// the dialect of the database does, as are the names of the columns.
+func (v Validation) query(d Dialect, tbl string, columns []string, isRightNumeric bool) string {
+	left, right := d.Quote("left"), d.Quote("right")
 	rhs := ""
 	if isRightNumeric {
 		// if we have a numeric RHS, then we just ignore the 'b.right' column
 		rhs = v.right.funcName
 	} else {
 		// otherwise, we refer to the 'b.right' column in the rhs of the comparison
-		rhs = " right "
+		rhs = " " + right + " "
 		if v.relative != "" {
 			rhs = rhs + " * " + v.relative
 		}
 	}
-	err = db.QueryRow(
-		"select count(*) " +
-			"from ( " +
-			"  (select " + strings.Join(columns, ",") + "," + dependentVar + " as left " +
-			"     from " + tbl + leftPredicates +
-			"  ) as a" +
-			" natural join " +
-			"  (select " + strings.Join(columns, ",") + "," + dependentVar + " as right " +
-			"     from " + tbl + rightPredicates +
-			"  ) as b" +
-			") " +
-			"where left " + v.op + rhs).Scan(&count)
+	return "select count(*) " +
+		"from ( " + d.Join(
+		"  (select "+quoteList(d, append(columns, v.left.funcName))+" as "+left+" "+
+			"     from "+tbl+v.left.where(d, v.globalTerms)+
+			"  )",
+		"  (select "+quoteList(d, append(columns, v.left.funcName))+" as "+right+" "+
+			"     from "+tbl+v.right.where(d, v.globalTerms)+
+			"  )",
+		columns) +
+		") " +
+		"where " + left + " " + v.op + rhs
+}
+
+// returns the query that Holds runs to check a comparison against a dataset,
+// if it's a whole table of a database; other datasets are never checked by a
+// query, so there is none for them
+func (v Validation) queryOn(ds Dataset) (query string, ok bool, err error) {
+	sd, ok := ds.(*sqlDataset)
+	if !ok || len(sd.where) > 0 {
+		return "", false, nil
+	}
+	isRightNumeric, err := v.checkComparison()
 	if err != nil {
-		return
+		return "", false, err
 	}
-	if count != valueCount {
-		return false, nil
+	columns, err := v.joinColumns(ds)
+	if err != nil {
+		return "", false, err
 	}
-	return true, nil
-	// }
+	return v.query(sd.dialect, sd.table, columns, isRightNumeric), true, nil
 }
@@ -217,3 +217,135 @@ func TestValidationWithoutPredicates(t *testing.T) {
 	assert.Nil(t, err)
 	assert.False(t, holds)
 }
+
+func loadWorkloadTable(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(`
+		CREATE TABLE workloads (
+			size INT,
+			workload VARCHAR(255),
+			method VARCHAR(255),
+			throughput FLOAT
+		)
+	`)
+	assert.Nil(t, err)
+
+	for _, row := range []string{
+		"1, 'read', 'a', 141", "1, 'read', 'b', 70",
+		"2, 'read', 'a', 152", "2, 'read', 'b', 72",
+		"1, 'write', 'a', 142", "1, 'write', 'b', 70",
+		"2, 'write', 'a', 136", "2, 'write', 'b', 149",
+	} {
+		_, err = db.Exec("INSERT INTO workloads VALUES(" + row + ")")
+		assert.Nil(t, err)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadWorkloadTable(t, db)
+
+	r, err := Evaluate(`
+	for each workload
+	expect
+	  throughput(method='a') > throughput(method='b') * 2
+	`, db, "workloads")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []GroupResult{
+		{"workload='read'", true},
+		{"workload='write'", false},
+	}, r.Groups)
+
+	r, err = Evaluate(`
+	for size > 1
+	for each workload, size
+	expect
+	  throughput(method='a') > throughput(method='b')
+	`, db, "workloads")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []GroupResult{
+		{"workload='read' and size=2", true},
+		{"workload='write' and size=2", false},
+	}, r.Groups)
+
+	holds, err := Holds(`
+	for each workload
+	for size < 2
+	expect
+	  throughput(method='a') > throughput(method='b') * 2
+	`, db, "workloads")
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+}
+
+func TestForEachErrors(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadWorkloadTable(t, db)
+
+	_, err := db.Exec("INSERT INTO workloads VALUES(3, 'scan', 'a', 12)")
+	assert.Nil(t, err)
+
+	_, err = Evaluate(`
+	for each workload
+	expect
+	  throughput(method='a') > throughput(method='b')
+	`, db, "workloads")
+
+	assert.NotNil(t, err)
+	assert.Equal(t,
+		"aver: for each workload='scan': no values associated to right-side predicates",
+		err.Error())
+
+	_, err = Evaluate(`
+	for size > 5
+	for each workload
+	expect
+	  throughput > 0
+	`, db, "workloads")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no values associated to 'for each' columns", err.Error())
+}
+
+func TestCounterexamples(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadWorkloadTable(t, db)
+
+	r, err := Evaluate(`
+	for each workload
+	expect
+	  throughput(method='a') > throughput(method='b') * 1.5
+	`, db, "workloads")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []PointResult{
+		{"workload='write'", "method='a' and size=2 and workload='write'", 136, 149 * 1.5, false},
+	}, r.Counterexamples)
+	assert.Equal(t, 2, len(r.Queries))
+	assert.Equal(t, "select count(*) from ( "+
+		"  (select \"size\",\"workload\",\"throughput\" as \"left\"      from workloads "+
+		"where \"method\"='a' and \"workload\"='read'  ) as a "+
+		"natural join "+
+		"  (select \"size\",\"workload\",\"throughput\" as \"right\"      from workloads "+
+		"where \"method\"='b' and \"workload\"='read'  ) as b) "+
+		"where \"left\" > \"right\"  * 1.5", r.Queries[0])
+
+	r, err = Evaluate("expect throughput < 150", db, "workloads")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []PointResult{
+		{"", "size=2 and workload='read' and method='a'", 152, 150, false},
+	}, r.Counterexamples)
+}
@@ -0,0 +1,170 @@
+// Package avertest checks validation statements from Go tests and benchmarks,
+// so that claims about performance can live next to the code producing the
+// data they refer to:
+//
+//	func TestOverhead(t *testing.T) {
+//		results := runExperiments()
+//		avertest.Expect(t, `
+//			expect throughput(method='ceph') > throughput(method='raw') * 0.9
+//		`, results)
+//	}
+//
+// When a statement doesn't hold, the failure is reported through t.Errorf
+// along with the points for which it doesn't hold and, for a table of a
+// database, the SQL query that aver.Holds runs to check it.
+package avertest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ivotron/aver"
+)
+
+// Expect checks that a statement holds on data, which is either an
+// aver.Dataset or a slice of structs (see aver.NewStructDataset). Placeholders
+// in the statement are bound to the values given in params. It returns
+// whether the statement holds.
+func Expect(t testing.TB, statement string, data interface{}, params ...aver.Params) bool {
+	t.Helper()
+
+	ds, ok := data.(aver.Dataset)
+	if !ok {
+		var err error
+		if ds, err = aver.NewStructDataset(data); err != nil {
+			t.Errorf("%s", err)
+			return false
+		}
+	}
+
+	v, err := aver.ParseValidation(statement)
+	if err != nil {
+		t.Errorf("%s", err)
+		return false
+	}
+	return check(t, "statement", v, ds, aver.Options{Params: aver.MergeParams(params...)})
+}
+
+// ExpectFile checks that every statement in a '.aver' file holds on the
+// results in a CSV file, whose first line is the header. It returns whether
+// all of them hold.
+func ExpectFile(t testing.TB, claimsFile string, resultsFile string) bool {
+	t.Helper()
+
+	vs, err := aver.ParseValidationFile(claimsFile)
+	if err != nil {
+		t.Errorf("%s: %s", claimsFile, err)
+		return false
+	}
+	f, err := os.Open(resultsFile)
+	if err != nil {
+		t.Errorf("%s", err)
+		return false
+	}
+	defer f.Close()
+	ds, err := aver.NewCSVDataset(f)
+	if err != nil {
+		t.Errorf("%s: %s", resultsFile, err)
+		return false
+	}
+
+	holds := true
+	for i, v := range vs {
+		name := fmt.Sprintf("statement #%d of %s", i+1, claimsFile)
+		if v.Label != "" {
+			name = "claim '" + v.Label + "'"
+		}
+		holds = check(t, name, v, ds, aver.Options{}) && holds
+	}
+	return holds
+}
+
+// evaluates a statement, reporting the outcome if it doesn't hold
+func check(t testing.TB, name string, v aver.Validation, ds aver.Dataset,
+	opts aver.Options) bool {
+
+	t.Helper()
+
+	r, err := v.Evaluate(ds, opts)
+	if err != nil {
+		t.Errorf("%s: %s", name, err)
+		return false
+	}
+	if r.Holds {
+		return true
+	}
+	t.Errorf("%s", report(name, v, r))
+	return false
+}
+
+// describes why a statement doesn't hold
+func report(name string, v aver.Validation, r aver.Result) string {
+	lines := []string{name + " doesn't hold"}
+	if v.Description != "" {
+		lines[0] += ": " + v.Description
+	}
+	for _, g := range r.Groups {
+		if !g.Holds {
+			lines = append(lines, "  fails for each "+g.Group)
+		}
+	}
+	for _, p := range r.Pairs {
+		if !p.Holds {
+			lines = append(lines, "  fails for "+inGroup(p.Left+" vs. "+p.Right, p.Group))
+		}
+	}
+	for _, p := range r.Counterexamples {
+		lines = append(lines, fmt.Sprintf("  counterexample: %s: %g (compared against %g)",
+			inGroup(p.Point, p.Group), p.Value, p.Bound))
+	}
+	for _, p := range r.Points {
+		if !p.Holds {
+			lines = append(lines, fmt.Sprintf("  counterexample: %s: %g (bound %g)",
+				inGroup(p.Point, p.Group), p.Value, p.Bound))
+		}
+	}
+	for _, s := range r.Statistics {
+		if !s.Holds {
+			lines = append(lines, fmt.Sprintf("  counterexample: %s: %g over %d values",
+				s.Group, s.Statistic, s.Count))
+		}
+	}
+	for _, c := range r.Correlations {
+		if !c.Holds {
+			lines = append(lines, fmt.Sprintf("  counterexample: %s: %g over %d rows",
+				inGroup(c.Coefficient, c.Group), c.Value, c.Count))
+		}
+	}
+	for _, rr := range r.Ranges {
+		if !rr.Holds {
+			lines = append(lines, fmt.Sprintf("  counterexample: %s / %s: between %g and %g",
+				inGroup(rr.Left, rr.Group), rr.Right, rr.Min, rr.Max))
+		}
+	}
+	for _, d := range r.Distributions {
+		if !d.Holds {
+			lines = append(lines, fmt.Sprintf("  counterexample: %s vs. %s: %s statistic %g",
+				inGroup(d.Left, d.Group), d.Right, d.Test, d.Statistic))
+		}
+	}
+	for _, d := range r.Dominations {
+		lines = append(lines, "  counterexample: "+inGroup(d.Dominated, d.Group)+
+			" dominated by "+d.Dominating)
+	}
+	if r.Missing > 0 {
+		lines = append(lines, fmt.Sprintf("  rows with missing values: %d", r.Missing))
+	}
+	for _, q := range r.Queries {
+		lines = append(lines, "  query: "+q)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func inGroup(s string, group string) string {
+	if group == "" {
+		return s
+	}
+	return group + ", " + s
+}
@@ -0,0 +1,169 @@
+package avertest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ivotron/aver"
+	"github.com/stretchr/testify/assert"
+)
+
+// records the failures reported by the functions of the package
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+type result struct {
+	Size       int     `aver:"size"`
+	Method     string  `aver:"method"`
+	Throughput float64 `aver:"throughput"`
+}
+
+var results = []result{
+	{1, "raw", 58}, {1, "ceph", 52.4},
+	{2, "raw", 58}, {2, "ceph", 50.1},
+}
+
+func TestExpect(t *testing.T) {
+	r := &recorder{TB: t}
+
+	assert.True(t, Expect(r,
+		"expect throughput(method='ceph') > throughput(method='raw') * 0.85", results))
+	assert.Equal(t, 0, len(r.errors))
+
+	assert.False(t, Expect(r,
+		"expect throughput(method='ceph') > throughput(method='raw') * $f", results,
+		aver.Params{"f": 0.9}))
+	assert.Equal(t, []string{"statement doesn't hold\n" +
+		"  counterexample: method='ceph' and size=2: 50.1 (compared against 52.2)"}, r.errors)
+
+	r.errors = nil
+	assert.False(t, Expect(r, "expect latency > 1", results))
+	assert.Equal(t, []string{"statement: aver: no such column: latency"}, r.errors)
+
+	r.errors = nil
+	assert.False(t, Expect(r, "expect throughput > 1", 42))
+	assert.Equal(t, []string{"aver: expecting a slice of structs; got int"}, r.errors)
+}
+
+func TestExpectFile(t *testing.T) {
+	path, err := ioutil.TempDir("", "avertest")
+	assert.Nil(t, err)
+
+	claims := filepath.Join(path, "claims.aver")
+	err = ioutil.WriteFile(claims, []byte(`
+	claim "ceph-overhead": "ceph is within 10% of raw"
+	expect
+	  throughput(method='ceph') > throughput(method='raw') * 0.9
+
+	expect
+	  throughput > 50
+	`), 0644)
+	assert.Nil(t, err)
+
+	data := filepath.Join(path, "results.csv")
+	err = ioutil.WriteFile(data, []byte("size,method,throughput\n"+
+		"1,raw,58\n1,ceph,52.4\n2,raw,58\n2,ceph,55.9\n"), 0644)
+	assert.Nil(t, err)
+
+	r := &recorder{TB: t}
+	assert.True(t, ExpectFile(r, claims, data))
+	assert.Equal(t, 0, len(r.errors))
+
+	err = ioutil.WriteFile(data, []byte("size,method,throughput\n"+
+		"1,raw,58\n1,ceph,52.4\n2,raw,58\n2,ceph,45\n"), 0644)
+	assert.Nil(t, err)
+
+	assert.False(t, ExpectFile(r, claims, data))
+	assert.Equal(t, 2, len(r.errors))
+	assert.Equal(t, "claim 'ceph-overhead' doesn't hold: ceph is within 10% of raw\n"+
+		"  counterexample: method='ceph' and size=2: 45 (compared against 52.2)", r.errors[0])
+	assert.Equal(t, "statement #2 of "+claims+" doesn't hold\n"+
+		"  counterexample: size=2 and method='ceph': 45 (compared against 50)", r.errors[1])
+
+	// the query that aver.Holds runs is reported for tables of a database
+	db, tbl, err := aver.MakeDb(data, "csv")
+	assert.Nil(t, err)
+	defer db.Close()
+	r.errors = nil
+	assert.False(t, Expect(r,
+		"expect throughput(method='ceph') > throughput(method='raw') * 0.9",
+		aver.NewSQLDataset(db, tbl)))
+	assert.Equal(t, 1, len(r.errors))
+	assert.Contains(t, r.errors[0], "\n  query: select count(*) from ( ")
+
+	r.errors = nil
+	assert.False(t, ExpectFile(r, claims, filepath.Join(path, "missing.csv")))
+	assert.Equal(t, 1, len(r.errors))
+}
+
+func TestCollector(t *testing.T) {
+	var c Collector
+	for _, method := range []string{"fast", "slow"} {
+		testing.Benchmark(func(b *testing.B) {
+			sum := 0
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < 100; j++ {
+					sum += j
+				}
+				if method == "slow" {
+					for j := 0; j < 10000; j++ {
+						sum += j
+					}
+				}
+			}
+			c.Record(b, Row{"method": method}, Row{"sum": sum})
+		})
+	}
+
+	ds, err := c.Dataset()
+	assert.Nil(t, err)
+	columns, err := ds.Schema()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"method", "ns_per_op", "sum"}, columns)
+
+	// every run of a benchmark replaces the row of the previous one
+	count := 0
+	err = ds.Scan([]string{"method"}, func([]interface{}) error {
+		count++
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	r := &recorder{TB: t}
+	assert.True(t, c.Expect(r, "expect ns_per_op(method='fast') < ns_per_op(method='slow')"))
+	assert.Equal(t, 0, len(r.errors))
+
+	assert.False(t, c.Expect(r, "expect ns_per_op(method='slow') < ns_per_op(method='fast')"))
+	assert.Equal(t, 1, len(r.errors))
+
+	// columns are the same regardless of the case they're given in
+	var mixed Collector
+	for _, key := range []string{"method", "Method"} {
+		testing.Benchmark(func(b *testing.B) {
+			mixed.Record(b, Row{key: key}, Row{"Sum": 1})
+		})
+	}
+	ds, err = mixed.Dataset()
+	assert.Nil(t, err)
+	columns, err = ds.Schema()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"method", "ns_per_op", "Sum"}, columns)
+	rows := make([][]interface{}, 0)
+	err = ds.Scan([]string{"method", "sum"}, func(values []interface{}) error {
+		rows = append(rows, values)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{{"method", int64(1)}, {"Method", int64(1)}}, rows)
+}
@@ -0,0 +1,158 @@
+package avertest
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ivotron/aver"
+)
+
+// Row holds the values of the columns of a row, e.g. the parameters of a
+// benchmark or the metrics it measures
+type Row map[string]interface{}
+
+// Collector gathers the results of benchmarks into a table, so that
+// statements can be checked on them once they've run:
+//
+//	func BenchmarkStore(b *testing.B) {
+//		var c avertest.Collector
+//		for _, method := range []string{"raw", "ceph"} {
+//			b.Run(method, func(b *testing.B) {
+//				for i := 0; i < b.N; i++ {
+//					store(method)
+//				}
+//				c.Record(b, avertest.Row{"method": method}, nil)
+//			})
+//		}
+//		c.Expect(b, "expect ns_per_op(method='ceph') < ns_per_op(method='raw') * 1.1")
+//	}
+//
+// Each row holds the configuration of a benchmark and the metrics it
+// measures, which include the time per iteration in nanoseconds
+// ('ns_per_op'). It is safe to record results from parallel benchmarks.
+type Collector struct {
+	mu      sync.Mutex
+	columns []string
+	metrics map[string]bool
+	names   []string
+	rows    []Row
+}
+
+// Record adds a row for the benchmark b, which is meant to be called at the
+// end of the benchmark function. The configuration holds the values of the
+// independent variables (e.g. the method or the size of the input), and
+// metrics the values measured by the benchmark besides ns_per_op (it can be
+// nil). Since the testing package runs a benchmark function several times with
+// an increasing b.N, each run replaces the row recorded by the previous one,
+// except for those starting over with b.N=1 (e.g. for each of the runs given
+// by -count).
+func (c *Collector) Record(b *testing.B, config Row, metrics Row) {
+	b.Helper()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.metrics == nil {
+		c.metrics = map[string]bool{"ns_per_op": true}
+	}
+	// values are kept under the name their column was first given, as column
+	// names are case-insensitive
+	row := Row{}
+	for _, k := range sortedKeys(config) {
+		row[c.addColumn(k)] = config[k]
+	}
+	column := c.addColumn("ns_per_op")
+	if b.N > 0 {
+		row[column] = float64(b.Elapsed().Nanoseconds()) / float64(b.N)
+	}
+	for _, k := range sortedKeys(metrics) {
+		row[c.addColumn(k)] = metrics[k]
+		c.metrics[strings.ToLower(k)] = true
+	}
+
+	if b.N > 1 {
+		for i := len(c.rows) - 1; i >= 0; i-- {
+			if c.names[i] == b.Name() {
+				c.rows[i] = row
+				return
+			}
+		}
+	}
+	c.names = append(c.names, b.Name())
+	c.rows = append(c.rows, row)
+}
+
+func sortedKeys(row Row) []string {
+	keys := make([]string, 0, len(row))
+	for k := range row {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (c *Collector) addColumn(column string) string {
+	for _, existing := range c.columns {
+		if strings.EqualFold(existing, column) {
+			return existing
+		}
+	}
+	c.columns = append(c.columns, column)
+	return column
+}
+
+// Dataset returns the rows recorded so far. Columns that were not given for
+// a row have missing values in it.
+func (c *Collector) Dataset() (aver.Dataset, error) {
+	return c.dataset(func(string) bool { return true })
+}
+
+// returns the recorded rows, keeping only the columns for which keep is true
+func (c *Collector) dataset(keep func(column string) bool) (aver.Dataset, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	columns := make([]string, 0, len(c.columns))
+	for _, column := range c.columns {
+		if keep(column) {
+			columns = append(columns, column)
+		}
+	}
+	rows := make([][]interface{}, len(c.rows))
+	for i, row := range c.rows {
+		rows[i] = make([]interface{}, len(columns))
+		for j, column := range columns {
+			rows[i][j] = row[column]
+		}
+	}
+	return aver.NewMemoryDataset(columns, rows)
+}
+
+// Expect checks that a statement holds on the rows recorded so far (see the
+// package-level Expect). Metrics that the statement doesn't refer to are left
+// out, as they would otherwise be taken as columns that identify each point.
+func (c *Collector) Expect(t testing.TB, statement string, params ...aver.Params) bool {
+	t.Helper()
+
+	v, err := aver.ParseValidation(statement)
+	if err != nil {
+		t.Errorf("%s", err)
+		return false
+	}
+	referred := make(map[string]bool)
+	for _, column := range v.NumericColumns() {
+		referred[strings.ToLower(column)] = true
+	}
+
+	ds, err := c.dataset(func(column string) bool {
+		column = strings.ToLower(column)
+		return !c.metrics[column] || referred[column]
+	})
+	if err != nil {
+		t.Errorf("%s", err)
+		return false
+	}
+	return check(t, "statement", v, ds, aver.Options{Params: aver.MergeParams(params...)})
+}
@@ -0,0 +1,197 @@
+package aver
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+)
+
+// CheckError lists the problems found by Check
+type CheckError struct {
+	Issues []string
+}
+
+func (e CheckError) Error() string {
+	return "aver: " + strings.Join(e.Issues, "; ")
+}
+
+// a column of a table, as seen by Check
+type column struct {
+	name    string
+	numeric bool
+	values  map[string]bool
+}
+
+func (c *column) contains(p predicate) bool {
+	if !c.numeric {
+		return c.values[p.literal]
+	}
+	lit, err := strconv.ParseFloat(p.literal, 64)
+	if err != nil {
+		return false
+	}
+	for v := range c.values {
+		if f, _ := strconv.ParseFloat(v, 64); f == lit {
+			return true
+		}
+	}
+	return false
+}
+
+// Check validates a statement against the schema and contents of a table
+// without evaluating it. It catches mistakes that would otherwise show up as
+// an opaque database error in Holds, or that would go unnoticed (e.g. a typo
+// in a column name that ends up being treated as a join column). A
+// CheckError is returned if the statement refers to unknown columns, compares
+// a column against a literal of a distinct type, uses an equality predicate
+// with a value that never occurs in the column, or if the dependent variable
+// is not numeric. Placeholders are bound to the values given in params.
+func Check(validation string, db *sql.DB, tbl string, params ...Params) error {
+	if db == nil {
+		return AverError{"null sql.DB pointer"}
+	}
+	return CheckDataset(validation, NewSQLDataset(db, tbl), params...)
+}
+
+// CheckDataset is like Check but validates the statement against any Dataset
+func CheckDataset(validation string, ds Dataset, params ...Params) error {
+	if ds == nil {
+		return AverError{"null dataset"}
+	}
+
+	v, err := ParseValidation(validation)
+	if err != nil {
+		return err
+	}
+	if v, err = v.bind(MergeParams(params...)); err != nil {
+		return err
+	}
+
+	mentioned := append(append([]string{}, v.NumericColumns()...), v.groupBy...)
+	terms := append(append(append(append([]predicate{},
+		v.globalTerms...), v.left.terms...), v.right.terms...), v.ranking...)
+	for _, p := range terms {
+		mentioned = append(mentioned, p.column)
+	}
+	columns, err := readColumns(ds, mentioned)
+	if err != nil {
+		return err
+	}
+
+	issues := make([]string, 0)
+
+	// dependent variable
+	// {
+	for _, name := range v.NumericColumns() {
+		c, ok := columns[strings.ToLower(name)]
+		if !ok {
+			issues = append(issues, "unknown column '"+name+"'")
+		} else if !c.numeric {
+			issues = append(issues, "dependent variable '"+name+"' is not numeric")
+		}
+	}
+	// }
+
+	// grouping columns
+	// {
+	for _, name := range v.groupBy {
+		if _, ok := columns[strings.ToLower(name)]; !ok {
+			issues = append(issues, "unknown column '"+name+"'")
+		}
+	}
+	// }
+
+	// predicates
+	// {
+	for _, p := range terms {
+		c, ok := columns[strings.ToLower(p.column)]
+		if !ok {
+			issues = append(issues, "unknown column '"+p.column+"'")
+			continue
+		}
+		if p.other {
+			continue
+		}
+		if c.numeric && p.quoted {
+			issues = append(issues,
+				"string literal in '"+p.String()+"' compared against numeric column")
+			continue
+		}
+		if !c.numeric && !p.quoted {
+			issues = append(issues,
+				"numeric literal in '"+p.String()+"' compared against non-numeric column")
+			continue
+		}
+		if p.op == "=" && !c.contains(p) {
+			issues = append(issues,
+				"value "+p.sqlLiteral()+" never occurs in column '"+p.column+"'")
+		}
+	}
+	// }
+
+	if len(issues) > 0 {
+		return CheckError{issues}
+	}
+	return nil
+}
+
+// reads the name and distinct values of the given columns of a dataset, which
+// are left out if the dataset doesn't have them. A column is considered
+// numeric if all its (non-empty) values can be parsed as numbers.
+func readColumns(ds Dataset, mentioned []string) (columns map[string]*column, err error) {
+	schema, err := ds.Schema()
+	if err != nil {
+		return
+	}
+	wanted := make(map[string]bool)
+	for _, name := range mentioned {
+		wanted[strings.ToLower(name)] = true
+	}
+	names := make([]string, 0)
+	for _, name := range schema {
+		if wanted[strings.ToLower(name)] {
+			names = append(names, name)
+			delete(wanted, strings.ToLower(name))
+		}
+	}
+	columns = make(map[string]*column)
+	if len(names) == 0 {
+		return
+	}
+
+	all := make([]*column, len(names))
+	for i, name := range names {
+		all[i] = &column{name: name, numeric: true, values: make(map[string]bool)}
+	}
+	err = ds.Scan(names, func(values []interface{}) error {
+		for i, value := range values {
+			var s string
+			switch v := value.(type) {
+			case nil:
+				continue
+			case int64:
+				s = strconv.FormatInt(v, 10)
+			case float64:
+				s = strconv.FormatFloat(v, 'g', -1, 64)
+			case string:
+				s = v
+			}
+			if s == "" {
+				continue
+			}
+			all[i].values[s] = true
+			if _, perr := strconv.ParseFloat(s, 64); perr != nil {
+				all[i].numeric = false
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range all {
+		columns[strings.ToLower(c.name)] = c
+	}
+	return
+}
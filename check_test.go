@@ -0,0 +1,137 @@
+package aver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckNullDBPointer(t *testing.T) {
+	err := Check("expect foo > 0", nil, "bar")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: null sql.DB pointer", err.Error())
+}
+
+func TestCheckValid(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadTestTable(t, db)
+
+	assert.Nil(t, Check(validation, db, "metrics"))
+	assert.Nil(t, Check("expect throughput > 0", db, "metrics"))
+}
+
+func TestCheckUnknownColumns(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadTestTable(t, db)
+
+	err := Check(`
+	for
+	  sise > 3
+	expect
+	  throughput(mehtod='ceph') > throughput(method='raw')
+	`, db, "metrics")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{
+		"unknown column 'sise'",
+		"unknown column 'mehtod'",
+	}, err.(CheckError).Issues)
+
+	err = Check("for each replicaton expect throughput > 0", db, "metrics")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: unknown column 'replicaton'", err.Error())
+
+	err = Check("expect thruput > 0", db, "metrics")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: unknown column 'thruput'", err.Error())
+}
+
+func TestCheckLiteralTypes(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadTestTable(t, db)
+
+	err := Check(`
+	for
+	  size > '3'
+	expect
+	  throughput(method=1) > throughput(method='raw')
+	`, db, "metrics")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{
+		"string literal in 'size>'3'' compared against numeric column",
+		"numeric literal in 'method=1' compared against non-numeric column",
+	}, err.(CheckError).Issues)
+}
+
+func TestCheckMissingValues(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadTestTable(t, db)
+
+	err := Check(`
+	for
+	  size = 6 and replication = 4
+	expect
+	  throughput(method='cehp') > throughput(method='raw')
+	`, db, "metrics")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{
+		"value 4 never occurs in column 'replication'",
+		"value 'cehp' never occurs in column 'method'",
+	}, err.(CheckError).Issues)
+}
+
+func TestCheckNonNumericDependentVariable(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadTestTable(t, db)
+
+	err := Check("expect method(size=1) > method(size=2)", db, "metrics")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: dependent variable 'method' is not numeric", err.Error())
+}
+
+func TestCheckTimestampColumns(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	_, err := db.Exec(`
+		CREATE TABLE runs (started TIMESTAMP, method VARCHAR(255), throughput FLOAT)
+	`)
+	assert.Nil(t, err)
+	_, err = db.Exec(`
+		INSERT INTO runs VALUES
+		  ('2016-05-02 10:00:00', 'raw', 110), ('2016-05-02 10:00:00', 'ceph', 100)
+	`)
+	assert.Nil(t, err)
+
+	assert.Nil(t, Check("expect throughput(method='raw') > throughput(method='ceph')", db, "runs"))
+
+	holds, err := Holds(
+		"expect throughput(method='raw') > throughput(method='ceph')", db, "runs")
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+
+	var started []interface{}
+	err = NewSQLDataset(db, "runs").Scan([]string{"started"}, func(values []interface{}) error {
+		started = append(started, values[0])
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"2016-05-02T10:00:00Z", "2016-05-02T10:00:00Z"}, started)
+}
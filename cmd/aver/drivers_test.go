@@ -0,0 +1,23 @@
+//go:build postgres && mysql
+// +build postgres,mysql
+
+package main
+
+import (
+	"database/sql"
+	"testing"
+)
+
+// checks that the optional drivers are linked when aver is built with
+// '-tags "postgres mysql"', which is otherwise never compiled
+func TestOptionalDrivers(t *testing.T) {
+	linked := make(map[string]bool)
+	for _, driver := range sql.Drivers() {
+		linked[driver] = true
+	}
+	for _, driver := range []string{"postgres", "mysql"} {
+		if !linked[driver] {
+			t.Errorf("driver '%s' is not linked", driver)
+		}
+	}
+}
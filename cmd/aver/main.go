@@ -1,6 +1,7 @@
 package main
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
 	"os"
@@ -12,15 +13,18 @@ import (
 )
 
 var dbConfig string
-var dataFile string
-var fileType string
+var dataFiles []string
+var pathTemplate string
+var format string
 var printVersion bool
 var toStdout bool
+var params []string
+var missing string
 
 func main() {
 
 	var cmd = &cobra.Command{
-		Use:   "aver \"<statement(s)>\"",
+		Use:   "aver \"<statement>\"",
 		Short: "Aver helps you automatically validate assertions on data",
 		Long:  ``,
 		Run:   Execute,
@@ -36,11 +40,30 @@ func main() {
 	cmd.Flags().BoolVarP(&printVersion, "version", "v", false, `Print program version.`)
 	cmd.Flags().StringVarP(&dbConfig, "dbconf", "c", "", `Name of file containing
 			database configuration. Format is JSON where only top-level elements are
-			considered. See http://github.com/ivotron/aver for supported drivers and
-			configuration examples.`)
-	cmd.Flags().StringVarP(&dataFile, "input", "i", "", `File to read data from.
-			Format is inferred from file extension. 'csv' and 'json' supported; 'csv'
-			is assumed for files without extension.`)
+			considered: 'driver', 'dsn' and 'table' (or 'file' for the 'sqlite'
+			driver). Only drivers linked into aver can be used: sqlite3 always, and
+			postgres or mysql when built with '-tags postgres' or '-tags mysql'.
+			Currently linked: `+strings.Join(sql.Drivers(), ", ")+`.`)
+	cmd.Flags().StringArrayVarP(&dataFiles, "input", "i", nil, `File to read data from,
+			or '-' for the standard input. Format is given by --format or detected from
+			the content and extension of the file. Files compressed with gzip, bzip2 or
+			zstd are decompressed. Can be given multiple times, or as a quoted glob
+			pattern (e.g. 'runs/*.csv'), to read several files into one table, which
+			has a '_source_file' column with the file each row is read from.`)
+	cmd.Flags().StringVarP(&pathTemplate, "path-template", "t", "", `Template for the
+			paths of input files, whose '{name}' parts are read into columns of the same
+			name (e.g. 'runs/{host}/{date}.csv' gives 'host' and 'date' columns).`)
+	cmd.Flags().StringVarP(&format, "format", "f", "", `Format of the input file: one of
+			`+strings.Join(aver.Formats(), ", ")+`. 'csv' files have a header line;
+			'gobench' is the output of 'go test -bench', 'fio' that of
+			'fio --output-format=json', 'hyperfine' the results exported by it (JSON or CSV)
+			and 'sysbench' the output of sysbench.`)
+	cmd.Flags().StringArrayVarP(&params, "param", "p", nil, `Value for a placeholder
+			in the statement, given as 'name=value' (e.g. --param factor=2 binds
+			'$factor'). Can be given multiple times.`)
+	cmd.Flags().StringVarP(&missing, "missing", "m", "", `Policy for rows with missing
+			(NULL or empty) values: 'fail' (default), 'skip' or 'error'. An 'on missing'
+			clause in the statement takes precedence.`)
 
 	cmd.Execute()
 }
@@ -53,30 +76,47 @@ func Execute(cmd *cobra.Command, args []string) {
 	if len(args) == 0 {
 		log.Fatalln(cmd.UsageString())
 	}
-	if dataFile != "" && dbConfig != "" {
+	if len(dataFiles) > 0 && dbConfig != "" {
 		log.Fatalln("ERROR: Options 'dbconf' and 'input' cannot be used simultaneously.")
 	}
 	if len(args) != 1 {
 		log.Fatalln("ERROR: Expecting one double-quoted string as argument.")
 	}
-	if strings.HasSuffix(dataFile, ".json") {
-		fileType = "json"
-		log.Fatalln("ERROR: JSON not supported yet.")
-	} else {
-		fileType = "csv"
+	if format != "" && dbConfig != "" {
+		log.Fatalln("ERROR: Options 'dbconf' and 'format' cannot be used simultaneously.")
+	}
+
+	bindings := aver.Params{}
+	for _, p := range params {
+		name, value, err := aver.ParseParam(p)
+		if err != nil {
+			log.Fatalln("ERROR: " + err.Error())
+		}
+		bindings[name] = value
 	}
 
+	var ds aver.Dataset
 	if dbConfig != "" {
-		dataFile = dbConfig
-		fileType = "config"
+		db, tblName, err := aver.MakeDb(dbConfig, "config")
+		if err != nil {
+			log.Fatalln("ERROR: " + err.Error())
+		}
+		ds = aver.NewSQLDataset(db, tblName)
+	} else {
+		var err error
+		ds, err = aver.LoadDatasets(dataFiles, aver.LoadOptions{
+			Format: format, PathTemplate: pathTemplate})
+		if err != nil {
+			log.Fatalln("ERROR: " + err.Error())
+		}
 	}
 
-	db, tblName, err := aver.MakeDb(dataFile, fileType)
-	if err != nil {
+	if err := aver.CheckDataset(args[0], ds, bindings); err != nil {
 		log.Fatalln("ERROR: " + err.Error())
 	}
 
-	holds, err := aver.Holds(args[0], db, tblName)
+	result, err := aver.EvaluateDataset(args[0], ds, aver.Options{
+		Params: bindings, Missing: aver.MissingPolicy(missing)})
 	if err != nil {
 		var stack [4096]byte
 		runtime.Stack(stack[:], true)
@@ -84,11 +124,56 @@ func Execute(cmd *cobra.Command, args []string) {
 		log.Fatalln("ERROR: " + err.Error())
 	}
 
-	db.Close()
-
 	if toStdout {
-		fmt.Printf("%t\n", holds)
-	} else if !holds {
+		for _, p := range result.Pairs {
+			if p.Group != "" {
+				fmt.Printf("%s, ", p.Group)
+			}
+			fmt.Printf("%s vs. %s: %t\n", p.Left, p.Right, p.Holds)
+		}
+		for _, g := range result.Groups {
+			fmt.Printf("%s: %t\n", g.Group, g.Holds)
+		}
+		if w := result.Worst; w != nil {
+			fmt.Printf("worst group: %s (%g over %d values)\n", w.Group, w.Statistic, w.Count)
+		}
+		for _, d := range result.Distributions {
+			if d.Test == "ks" {
+				fmt.Printf("%s vs. %s: D=%g, p=%g\n", d.Left, d.Right, d.Statistic, d.PValue)
+			} else {
+				fmt.Printf("%s vs. %s: violation=%g\n", d.Left, d.Right, d.Statistic)
+			}
+		}
+		for _, c := range result.Correlations {
+			if c.Group != "" {
+				fmt.Printf("%s, ", c.Group)
+			}
+			fmt.Printf("%s: %g over %d rows\n", c.Coefficient, c.Value, c.Count)
+		}
+		for _, p := range result.Points {
+			fmt.Printf("%s: %g (bound %g): %t\n", p.Point, p.Value, p.Bound, p.Holds)
+		}
+		for _, r := range result.Ranges {
+			if r.Group != "" {
+				fmt.Printf("%s, ", r.Group)
+			}
+			fmt.Printf("%s / %s: ratio between %g and %g over %d points\n",
+				r.Left, r.Right, r.Min, r.Max, r.Count)
+		}
+		for _, d := range result.Dominations {
+			fmt.Printf("%s dominated by %s\n", d.Dominated, d.Dominating)
+		}
+		for _, o := range result.Outliers {
+			fmt.Printf("excluded outlier: %s (%g)\n", o.Point, o.Value)
+		}
+		if result.Missing > 0 {
+			fmt.Printf("rows with missing values: %d\n", result.Missing)
+		}
+		fmt.Printf("%t\n", result.Holds)
+	} else if !result.Holds {
+		if result.Missing > 0 {
+			log.Printf("rows with missing values: %d\n", result.Missing)
+		}
 		os.Exit(1)
 	}
 }
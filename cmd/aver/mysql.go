@@ -0,0 +1,8 @@
+//go:build mysql
+// +build mysql
+
+package main
+
+// links the MySQL driver, so that database configurations can use the 'mysql'
+// driver when aver is built with 'go build -tags mysql'
+import _ "github.com/go-sql-driver/mysql"
@@ -0,0 +1,8 @@
+//go:build postgres
+// +build postgres
+
+package main
+
+// links the Postgres driver, so that database configurations can use the
+// 'postgres' driver when aver is built with 'go build -tags postgres'
+import _ "github.com/lib/pq"
@@ -0,0 +1,59 @@
+package aver
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compression formats, told apart by the magic bytes data starts with
+var compressions = []struct {
+	extension string
+	magic     []byte
+	reader    func(r io.Reader) (io.ReadCloser, error)
+}{
+	{".gz", []byte{0x1f, 0x8b}, func(r io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(r)
+	}},
+	{".bz2", []byte("BZh"), func(r io.Reader) (io.ReadCloser, error) {
+		return ioutil.NopCloser(bzip2.NewReader(r)), nil
+	}},
+	{".zst", []byte{0x28, 0xb5, 0x2f, 0xfd}, func(r io.Reader) (io.ReadCloser, error) {
+		d, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	}},
+}
+
+// returns a reader of the decompressed data if it is compressed with gzip,
+// bzip2 or zstd, or of the data as is otherwise
+func decompress(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	for _, c := range compressions {
+		if magic, err := br.Peek(len(c.magic)); err == nil && bytes.Equal(magic, c.magic) {
+			return c.reader(br)
+		}
+	}
+	return ioutil.NopCloser(br), nil
+}
+
+// strips the extension of a compressed file, e.g. 'results.csv' for
+// 'results.csv.gz'
+func uncompressedName(file string) string {
+	ext := strings.ToLower(filepath.Ext(file))
+	for _, c := range compressions {
+		if ext == c.extension {
+			return strings.TrimSuffix(file, filepath.Ext(file))
+		}
+	}
+	return file
+}
@@ -0,0 +1,94 @@
+package aver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+const compressTestData = "size,method,throughput\n1,raw,58\n1,ceph,55.9\n"
+
+// compressTestData, as compressed by 'bzip2 -9'
+var compressTestBzip2 = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x78, 0x1c,
+	0x31, 0xa3, 0x00, 0x00, 0x12, 0x59, 0x80, 0x00, 0x10, 0x00, 0x05, 0x22,
+	0x60, 0x2e, 0xe2, 0xde, 0x90, 0x20, 0x00, 0x31, 0x40, 0x06, 0x23, 0x4d,
+	0x34, 0x68, 0x54, 0xfd, 0x53, 0x13, 0x34, 0xd3, 0x53, 0x0d, 0x32, 0x4d,
+	0x68, 0x8d, 0x54, 0x08, 0x65, 0x01, 0x68, 0x03, 0x58, 0xed, 0xf2, 0xae,
+	0xda, 0xe0, 0x72, 0xd9, 0xeb, 0xd8, 0x8c, 0x14, 0xa4, 0x96, 0x67, 0xe8,
+	0xbb, 0x92, 0x29, 0xc2, 0x84, 0x83, 0xc0, 0xe1, 0x8d, 0x18,
+}
+
+func TestDecompress(t *testing.T) {
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, err := w.Write([]byte(compressTestData))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+
+	var zst bytes.Buffer
+	zw, err := zstd.NewWriter(&zst)
+	assert.Nil(t, err)
+	_, err = zw.Write([]byte(compressTestData))
+	assert.Nil(t, err)
+	assert.Nil(t, zw.Close())
+
+	for _, data := range [][]byte{
+		[]byte(compressTestData), gz.Bytes(), compressTestBzip2, zst.Bytes(),
+	} {
+		r, err := decompress(bytes.NewReader(data))
+		assert.Nil(t, err)
+		b, err := ioutil.ReadAll(r)
+		assert.Nil(t, err)
+		assert.Nil(t, r.Close())
+		assert.Equal(t, compressTestData, string(b))
+	}
+
+	assert.Equal(t, "runs/results.csv", uncompressedName("runs/results.csv.gz"))
+	assert.Equal(t, "results.csv", uncompressedName("results.csv.ZST"))
+	assert.Equal(t, "results.csv", uncompressedName("results.csv"))
+}
+
+func TestLoadCompressedDataset(t *testing.T) {
+	path, err := ioutil.TempDir("", "aver")
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(path))
+
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, err = w.Write([]byte(goBenchOutput))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+	assert.Nil(t, ioutil.WriteFile("results.bench.gz", gz.Bytes(), 0644))
+	assert.Nil(t, ioutil.WriteFile("results.csv.bz2", compressTestBzip2, 0644))
+
+	ds, err := LoadDataset("results.bench.gz", LoadOptions{})
+	assert.Nil(t, err)
+	_, ok := ds.(MetricDataset)
+	assert.True(t, ok)
+
+	ds, err = LoadDataset("results.csv.bz2", LoadOptions{Format: "csv"})
+	assert.Nil(t, err)
+	r, err := EvaluateDataset(
+		"expect throughput(method='ceph') > throughput(method='raw') * 0.9", ds, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+
+	// the standard input
+	stdin := os.Stdin
+	defer func() { os.Stdin = stdin }()
+	os.Stdin, err = os.Open("results.bench.gz")
+	assert.Nil(t, err)
+	defer os.Stdin.Close()
+
+	ds, err = LoadDataset("-", LoadOptions{})
+	assert.Nil(t, err)
+	columns, err := ds.Schema()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"gomaxprocs", "name"}, columns[:2])
+}
@@ -0,0 +1,57 @@
+package aver
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CorrelationResult is the outcome of a correlation statement (e.g.
+// 'corr(throughput, size) > 0.9') for the rows of a group
+type CorrelationResult struct {
+	// Group is the 'for each' group the result belongs to (if any)
+	Group string
+	// Coefficient is the name of the correlation coefficient ('corr',
+	// 'spearman' or 'kendall') and Value the one observed over Count rows
+	Coefficient string
+	Value       float64
+	Count       int
+	Holds       bool
+}
+
+// evaluates a correlation statement over the rows selected by the global
+// predicates. Rows where any of the two columns is missing are ignored.
+func (v Validation) correlate(ds Dataset) (r CorrelationResult, err error) {
+	coefficient, ok := correlations[v.correlation]
+	if !ok {
+		return r, AverError{"unknown correlation coefficient " + v.correlation}
+	}
+	threshold, err := strconv.ParseFloat(v.right.funcName, 64)
+	if err != nil {
+		return r, AverError{
+			"Expecting numeric threshold for " + v.correlation + "; got " + v.right.funcName}
+	}
+
+	points, err := selectPoints(
+		ds, nil, []string{v.left.funcName, v.covariate}, v.globalTerms)
+	if err != nil {
+		return
+	}
+	xs, ys := make([]float64, 0, len(points)), make([]float64, 0, len(points))
+	for _, p := range present(points) {
+		xs, ys = append(xs, p.values[0]), append(ys, p.values[1])
+	}
+	if len(xs) < 2 {
+		return r, AverError{"correlation requires at least two rows with values for '" +
+			v.left.funcName + "' and '" + v.covariate + "'"}
+	}
+
+	r = CorrelationResult{Coefficient: v.correlation, Count: len(xs)}
+	r.Value = coefficient(xs, ys)
+	if math.IsNaN(r.Value) {
+		return r, AverError{"correlation between '" + v.left.funcName + "' and '" +
+			v.covariate + "' is undefined since one of them is constant"}
+	}
+	r.Holds = compare(r.Value, strings.TrimSpace(v.op), threshold)
+	return
+}
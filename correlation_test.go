@@ -0,0 +1,76 @@
+package aver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelation(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadWorkloadTable(t, db)
+
+	r, err := Evaluate(
+		"for method='a' and workload='read' expect corr(throughput, size) > 0.9", db, "workloads")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 1, len(r.Correlations))
+	assert.Equal(t, "corr", r.Correlations[0].Coefficient)
+	assert.Equal(t, 2, r.Correlations[0].Count)
+	assert.InDelta(t, 1.0, r.Correlations[0].Value, 1e-9)
+
+	r, err = Evaluate(`
+	for each workload
+	for method='a'
+	expect
+	  spearman(throughput, size) > 0
+	`, db, "workloads")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []GroupResult{
+		{"workload='read'", true},
+		{"workload='write'", false},
+	}, r.Groups)
+	assert.Equal(t, 2, len(r.Correlations))
+	assert.Equal(t, "workload='write'", r.Correlations[1].Group)
+	assert.InDelta(t, -1.0, r.Correlations[1].Value, 1e-9)
+
+	holds, err := Holds(
+		"for method='b' expect kendall(throughput, size) >= $min", db, "workloads",
+		Params{"min": 0.5})
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+
+	holds, err = Holds("for method='b' expect corr(throughput, size) < -0.5", db, "workloads")
+
+	assert.Nil(t, err)
+	assert.False(t, holds)
+}
+
+func TestCorrelationErrors(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadWorkloadTable(t, db)
+
+	_, err := Holds(
+		"for method='a' and size=1 expect corr(throughput, size) > 0.9", db, "workloads")
+
+	assert.NotNil(t, err)
+	assert.Equal(t,
+		"aver: correlation between 'throughput' and 'size' is undefined since one of them is constant",
+		err.Error())
+
+	_, err = Holds(
+		"for method='c' expect corr(throughput, size) > 0.9", db, "workloads")
+
+	assert.NotNil(t, err)
+	assert.Equal(t,
+		"aver: correlation requires at least two rows with values for 'throughput' and 'size'",
+		err.Error())
+}
@@ -0,0 +1,66 @@
+package aver
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// NewCSVDataset reads a CSV file, whose first line is the header, into a
+// Dataset held in memory. Values that can be parsed as numbers are numeric;
+// empty cells are kept as empty strings, which statements treat as missing
+// values.
+func NewCSVDataset(r io.Reader) (Dataset, error) {
+	return readCSV(csv.NewReader(r))
+}
+
+// the loader of the 'csv' format, whose 'delimiter' setting is the character
+// that separates values (',' by default)
+func loadCSV(r io.Reader, opts LoadOptions) (Dataset, error) {
+	reader := csv.NewReader(r)
+	if d, ok := opts.Settings["delimiter"]; ok {
+		delimiter := []rune(d)
+		if len(delimiter) != 1 {
+			return nil, AverError{"expecting a single character as delimiter; got '" + d + "'"}
+		}
+		reader.Comma = delimiter[0]
+	}
+	return readCSV(reader)
+}
+
+func readCSV(reader *csv.Reader) (Dataset, error) {
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, AverError{"empty CSV file"}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]interface{}, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := make([]interface{}, len(record))
+		for i, cell := range record {
+			row[i] = csvValue(cell)
+		}
+		rows = append(rows, row)
+	}
+	return NewMemoryDataset(header, rows)
+}
+
+func csvValue(cell string) interface{} {
+	if i, err := strconv.ParseInt(cell, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(cell, 64); err == nil {
+		return f
+	}
+	return cell
+}
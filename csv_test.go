@@ -0,0 +1,42 @@
+package aver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVDataset(t *testing.T) {
+	ds, err := NewCSVDataset(strings.NewReader(`size,method,throughput
+1,raw,58
+1,ceph,52.4
+2,raw,
+2,ceph,55.9
+`))
+	assert.Nil(t, err)
+
+	rows := make([][]interface{}, 0)
+	err = ds.Scan([]string{"size", "method", "throughput"}, func(values []interface{}) error {
+		rows = append(rows, values)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{
+		{int64(1), "raw", int64(58)}, {int64(1), "ceph", 52.4},
+		{int64(2), "raw", ""}, {int64(2), "ceph", 55.9},
+	}, rows)
+
+	r, err := EvaluateDataset("expect throughput(method='ceph') > throughput(method='raw') * 0.9",
+		ds, Options{Missing: MissingSkip})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 1, r.Missing)
+
+	_, err = NewCSVDataset(strings.NewReader(""))
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: empty CSV file", err.Error())
+
+	_, err = NewCSVDataset(strings.NewReader("size,method\n1,raw,58\n"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,504 @@
+package aver
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dataset is a table of values that statements are evaluated against. Holds
+// and Evaluate work on a table of a database; EvaluateDataset works on any
+// Dataset, such as one held in memory (see NewMemoryDataset).
+type Dataset interface {
+	// Schema returns the name of the columns of the dataset
+	Schema() ([]string, error)
+
+	// Filter returns a dataset with the rows that satisfy every one of the
+	// given predicates
+	Filter(predicates ...Predicate) Dataset
+
+	// Scan calls fn with the values of the given columns for each of the rows
+	// of the dataset. Values are either nil (for NULL), int64, float64 or
+	// string. Scanning stops at the first error returned by fn.
+	Scan(columns []string, fn func(values []interface{}) error) error
+}
+
+// MetricDataset is a Dataset that tells which of its columns are metrics, i.e.
+// hold measurements rather than the independent variables that identify each
+// point. Statements referring to one metric ignore the rest of them, which
+// would otherwise be taken as columns that both sides of a comparison are
+// joined on. Columns with values that follow from the independent variables,
+// such as the name given to each configuration, are better told as metrics
+// too, since they differ between the sides of a comparison.
+type MetricDataset interface {
+	Dataset
+	Metrics() []string
+}
+
+// WithMetrics returns a MetricDataset for ds with the given metric columns
+func WithMetrics(ds Dataset, metrics ...string) MetricDataset {
+	return &metricDataset{ds, metrics}
+}
+
+type metricDataset struct {
+	Dataset
+	metrics []string
+}
+
+func (ds *metricDataset) Metrics() []string {
+	return ds.metrics
+}
+
+func (ds *metricDataset) Filter(predicates ...Predicate) Dataset {
+	return &metricDataset{ds.Dataset.Filter(predicates...), ds.metrics}
+}
+
+// RunDataset is a MetricDataset that tells which of its columns number the
+// runs of a configuration, i.e. its repeated measurements. Runs identify the
+// points of a comparison, so that the runs of both sides are paired up by
+// their number, whereas statements that look at the repetitions of each
+// configuration as a whole, such as variability statements or those excluding
+// outliers, leave them out.
+type RunDataset interface {
+	MetricDataset
+	Runs() []string
+}
+
+// WithRuns returns a RunDataset for ds with the given run columns
+func WithRuns(ds MetricDataset, runs ...string) RunDataset {
+	return &runDataset{ds, runs}
+}
+
+type runDataset struct {
+	MetricDataset
+	runs []string
+}
+
+func (ds *runDataset) Runs() []string {
+	return ds.runs
+}
+
+func (ds *runDataset) Filter(predicates ...Predicate) Dataset {
+	return &runDataset{ds.MetricDataset.Filter(predicates...).(MetricDataset), ds.runs}
+}
+
+// removes the run columns of a dataset, if it tells them, from the given ones
+func withoutRuns(ds Dataset, columns []string) []string {
+	r, ok := ds.(RunDataset)
+	if !ok {
+		return columns
+	}
+	isRun := make(map[string]bool)
+	for _, c := range r.Runs() {
+		isRun[strings.ToLower(c)] = true
+	}
+	kept := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if !isRun[strings.ToLower(c)] {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// obtains the metric columns of a dataset (in lower case), if it tells them
+func metricsOf(ds Dataset) map[string]bool {
+	metrics := make(map[string]bool)
+	if m, ok := ds.(MetricDataset); ok {
+		for _, c := range m.Metrics() {
+			metrics[strings.ToLower(c)] = true
+		}
+	}
+	return metrics
+}
+
+// Predicate is a '<column> <op> <value>' term of a conjunction, where op is
+// one of =, <>, <, <=, > and >=, and the value is either a float64 or a
+// string
+type Predicate struct {
+	Column string
+	Op     string
+	Value  interface{}
+}
+
+func (p Predicate) String() string {
+	return p.Column + p.Op + p.literal()
+}
+
+func (p Predicate) literal() string {
+	if s, ok := p.Value.(string); ok {
+		return "'" + strings.Replace(s, "'", "''", -1) + "'"
+	}
+	if f, ok := p.Value.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(p.Value)
+}
+
+// converts a (bound) predicate of a statement
+func (p predicate) export() Predicate {
+	if !p.quoted {
+		if f, err := strconv.ParseFloat(p.literal, 64); err == nil {
+			return Predicate{p.column, p.op, f}
+		}
+	}
+	return Predicate{p.column, p.op, strings.Replace(p.literal, "''", "'", -1)}
+}
+
+// filters a dataset with the given conjunctions
+func filter(ds Dataset, conjunctions ...[]predicate) Dataset {
+	predicates := make([]Predicate, 0)
+	for _, c := range conjunctions {
+		for _, p := range c {
+			predicates = append(predicates, p.export())
+		}
+	}
+	if len(predicates) == 0 {
+		return ds
+	}
+	return ds.Filter(predicates...)
+}
+
+// whether a value satisfies a predicate. NULL values satisfy none, and values
+// are compared numerically whenever both sides are numbers (or strings that
+// can be parsed as such).
+func (p Predicate) matches(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	c := compareValues(value, p.Value)
+	switch p.Op {
+	case "=":
+		return c == 0
+	case "<>":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+// orders two (non-NULL) values, numerically if both are numbers, or as
+// strings otherwise
+func compareValues(a, b interface{}) int {
+	x, aNumeric := toFloat(a)
+	y, bNumeric := toFloat(b)
+	if aNumeric && bNumeric {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// orders two values the way SQL does: NULL first, then numbers, then strings
+func orderValues(a, b interface{}) int {
+	rank := func(value interface{}) int {
+		switch value.(type) {
+		case nil:
+			return 0
+		case int64, float64:
+			return 1
+		}
+		return 2
+	}
+	if rank(a) != rank(b) {
+		return rank(a) - rank(b)
+	}
+	switch rank(a) {
+	case 0:
+		return 0
+	case 1:
+		return compareValues(a, b)
+	}
+	return strings.Compare(a.(string), b.(string))
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// normalizes a value to one of the types that a Dataset yields
+func normalize(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, int64, float64, string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float32:
+		return float64(v), nil
+	case bool:
+		if v {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case time.Time:
+		// as drivers return TIMESTAMP and DATETIME columns
+		return v.Format(time.RFC3339Nano), nil
+	}
+	return nil, AverError{fmt.Sprintf("unsupported value type %T", value)}
+}
+
+// a table of a database
+type sqlDataset struct {
+	db      *sql.DB
+	dialect Dialect
+	table   string
+	where   []Predicate
+}
+
+// NewSQLDataset returns the Dataset for a table of a database, whose dialect
+// is given by the driver of the database (see RegisterDialect)
+func NewSQLDataset(db *sql.DB, table string) Dataset {
+	d := SQLite
+	if db != nil {
+		d = dialectOf(db)
+	}
+	return NewSQLDatasetWithDialect(db, table, d)
+}
+
+// NewSQLDatasetWithDialect returns the Dataset for a table of a database with
+// the given dialect
+func NewSQLDatasetWithDialect(db *sql.DB, table string, d Dialect) Dataset {
+	return &sqlDataset{db: db, dialect: d, table: table}
+}
+
+func (ds *sqlDataset) Schema() (columns []string, err error) {
+	rows, err := ds.db.Query(ds.dialect.SchemaQuery(ds.table))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	seen := make(map[string]bool)
+	for rows.Next() {
+		var column string
+		if err = rows.Scan(&column); err != nil {
+			return nil, err
+		}
+		// tables of the same name in other schemas list them again
+		if !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, AverError{"no such table: " + ds.table}
+	}
+	return
+}
+
+func (ds *sqlDataset) Filter(predicates ...Predicate) Dataset {
+	return &sqlDataset{ds.db, ds.dialect, ds.table,
+		append(append([]Predicate{}, ds.where...), predicates...)}
+}
+
+func (ds *sqlDataset) Scan(columns []string, fn func(values []interface{}) error) error {
+	terms := make([]string, len(ds.where))
+	for i, p := range ds.where {
+		terms[i] = ds.dialect.Quote(p.Column) + p.Op + p.literal()
+	}
+	rows, err := ds.db.Query(
+		"select " + quoteList(ds.dialect, columns) + " from " + ds.table + whereClause(terms...))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err = rows.Scan(pointers...); err != nil {
+			return err
+		}
+		row := make([]interface{}, len(values))
+		for i, value := range values {
+			if row[i], err = normalize(value); err != nil {
+				return err
+			}
+		}
+		if err = fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// a table held in memory
+type memoryDataset struct {
+	columns []string
+	rows    [][]interface{}
+	err     error
+}
+
+// NewMemoryDataset returns a Dataset holding the given rows, each of which
+// has a value for each of the columns. Values can be nil, strings, booleans or
+// numbers of any type.
+func NewMemoryDataset(columns []string, rows [][]interface{}) (Dataset, error) {
+	ds := &memoryDataset{columns: columns, rows: make([][]interface{}, len(rows))}
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return nil, AverError{fmt.Sprintf(
+				"row %d has %d values but there are %d columns", i+1, len(row), len(columns))}
+		}
+		ds.rows[i] = make([]interface{}, len(row))
+		for j, value := range row {
+			var err error
+			if ds.rows[i][j], err = normalize(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ds, nil
+}
+
+func (ds *memoryDataset) Schema() ([]string, error) {
+	return append([]string{}, ds.columns...), ds.err
+}
+
+// column names are case-insensitive, as they are in SQL
+func (ds *memoryDataset) index(column string) (int, error) {
+	for i, c := range ds.columns {
+		if strings.EqualFold(c, column) {
+			return i, nil
+		}
+	}
+	return 0, AverError{"no such column: " + column}
+}
+
+func (ds *memoryDataset) Filter(predicates ...Predicate) Dataset {
+	filtered := &memoryDataset{columns: ds.columns, err: ds.err}
+	indexes := make([]int, len(predicates))
+	for i, p := range predicates {
+		if indexes[i], filtered.err = ds.index(p.Column); filtered.err != nil {
+			return filtered
+		}
+	}
+	for _, row := range ds.rows {
+		ok := true
+		for i, p := range predicates {
+			ok = ok && p.matches(row[indexes[i]])
+		}
+		if ok {
+			filtered.rows = append(filtered.rows, row)
+		}
+	}
+	return filtered
+}
+
+func (ds *memoryDataset) Scan(columns []string, fn func(values []interface{}) error) error {
+	if ds.err != nil {
+		return ds.err
+	}
+	indexes := make([]int, len(columns))
+	for i, c := range columns {
+		var err error
+		if indexes[i], err = ds.index(c); err != nil {
+			return err
+		}
+	}
+	for _, row := range ds.rows {
+		values := make([]interface{}, len(columns))
+		for i, j := range indexes {
+			values[i] = row[j]
+		}
+		if err := fn(values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// builds a table out of rows whose columns show up as they are read, as is
+// the case for the outputs of tools that adapters parse. Columns are in the
+// order they first show up; rows have no value for the columns that showed up
+// after them. Column names are case-insensitive, as they are in SQL.
+type tableBuilder struct {
+	columns []string
+	index   map[string]int
+	rows    [][]interface{}
+}
+
+// the position of a column, which is added if it hasn't shown up yet
+func (t *tableBuilder) column(name string) int {
+	if t.index == nil {
+		t.index = make(map[string]int)
+	}
+	key := strings.ToLower(name)
+	if i, ok := t.index[key]; ok {
+		return i
+	}
+	t.index[key] = len(t.columns)
+	t.columns = append(t.columns, name)
+	return t.index[key]
+}
+
+// whether a column has shown up
+func (t *tableBuilder) has(name string) bool {
+	_, ok := t.index[strings.ToLower(name)]
+	return ok
+}
+
+// adds a row with the given values, indexed by the position of their column
+func (t *tableBuilder) add(values map[int]interface{}) {
+	row := make([]interface{}, len(t.columns))
+	for i, value := range values {
+		row[i] = value
+	}
+	t.rows = append(t.rows, row)
+}
+
+func (t *tableBuilder) table() (columns []string, rows [][]interface{}) {
+	for i, row := range t.rows {
+		for len(row) < len(t.columns) {
+			row = append(row, nil)
+		}
+		t.rows[i] = row
+	}
+	return t.columns, t.rows
+}
@@ -0,0 +1,222 @@
+package aver
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// copies a table of a database into memory
+func memoryCopy(t *testing.T, db *sql.DB, tbl string) Dataset {
+	table := NewSQLDataset(db, tbl)
+	columns, err := table.Schema()
+	assert.Nil(t, err)
+
+	rows := make([][]interface{}, 0)
+	err = table.Scan(columns, func(values []interface{}) error {
+		rows = append(rows, values)
+		return nil
+	})
+	assert.Nil(t, err)
+
+	ds, err := NewMemoryDataset(columns, rows)
+	assert.Nil(t, err)
+	return ds
+}
+
+func TestMemoryDataset(t *testing.T) {
+	ds, err := NewMemoryDataset([]string{"size", "method", "throughput"}, [][]interface{}{
+		{1, "a", 150.0}, {1, "b", float32(120)}, {2, "a", nil}, {2, "b", "220"},
+	})
+	assert.Nil(t, err)
+
+	scan := func(ds Dataset, columns ...string) (rows [][]interface{}, err error) {
+		err = ds.Scan(columns, func(values []interface{}) error {
+			rows = append(rows, values)
+			return nil
+		})
+		return
+	}
+
+	rows, err := scan(ds, "Method", "size")
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{
+		{"a", int64(1)}, {"b", int64(1)}, {"a", int64(2)}, {"b", int64(2)},
+	}, rows)
+
+	rows, err = scan(ds.Filter(Predicate{"throughput", ">", 130.0}), "size", "method")
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{{int64(1), "a"}, {int64(2), "b"}}, rows)
+
+	rows, err = scan(ds.Filter(
+		Predicate{"method", "<>", "a"}, Predicate{"size", "<=", 1.0}), "throughput")
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{{float64(120)}}, rows)
+
+	_, err = scan(ds.Filter(Predicate{"workload", "=", "read"}), "size")
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no such column: workload", err.Error())
+
+	_, err = scan(ds, "latency")
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no such column: latency", err.Error())
+
+	_, err = NewMemoryDataset([]string{"size", "throughput"}, [][]interface{}{{1, 2}, {3}})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: row 2 has 1 values but there are 2 columns", err.Error())
+
+	_, err = NewMemoryDataset([]string{"size"}, [][]interface{}{{[]int{1}}})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: unsupported value type []int", err.Error())
+}
+
+func TestRunDataset(t *testing.T) {
+	ds, err := NewMemoryDataset([]string{"method", "run", "throughput"}, [][]interface{}{
+		{"a", 1, 110.0}, {"a", 2, 90.0}, {"a", 3, 100.0},
+		{"b", 1, 100.0}, {"b", 2, 80.0}, {"b", 3, 95.0},
+	})
+	assert.Nil(t, err)
+	runs := WithRuns(WithMetrics(ds, "throughput"), "run")
+
+	// runs are paired up by their number, unlike repetitions that aren't told
+	r, err := EvaluateDataset(
+		"expect throughput(method='a') > throughput(method='b')", runs, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	_, err = EvaluateDataset("expect throughput(method='a') > throughput(method='b')",
+		WithMetrics(ds, "throughput", "run"), Options{})
+	assert.NotNil(t, err)
+
+	// while the runs of a configuration make up one group
+	r, err = EvaluateDataset("expect cv(throughput) < 0.15", runs, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, []string{"method='a'", "method='b'"},
+		[]string{r.Statistics[0].Group, r.Statistics[1].Group})
+	assert.Equal(t, 3, r.Statistics[0].Count)
+
+	r, err = EvaluateDataset(
+		"expect throughput(method='a') > throughput(method='b') "+
+			"excluding outliers by iqr 1.5", runs, Options{})
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+
+	_, ok := runs.Filter(Predicate{"method", "=", "a"}).(RunDataset)
+	assert.True(t, ok)
+}
+
+func TestEvaluateDataset(t *testing.T) {
+	ds, err := NewMemoryDataset(
+		[]string{"size", "workload", "method", "throughput"}, [][]interface{}{
+			{1, "read", "a", 141}, {1, "read", "b", 70},
+			{2, "read", "a", 152}, {2, "read", "b", 72},
+			{1, "write", "a", 142}, {1, "write", "b", 70},
+			{2, "write", "a", 136}, {2, "write", "b", 149},
+		})
+	assert.Nil(t, err)
+
+	r, err := EvaluateDataset(`
+	for each workload
+	expect
+	  throughput(method='a') > throughput(method='b') * 2
+	`, ds, Options{})
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []GroupResult{
+		{"workload='read'", true},
+		{"workload='write'", false},
+	}, r.Groups)
+
+	r, err = EvaluateDataset(`
+	for workload='read'
+	expect
+	  throughput(method='b') < throughput(method=*other*)
+	`, ds, Options{})
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, []PairResult{{"", "method='b'", "method='a'", true}}, r.Pairs)
+
+	_, err = EvaluateDataset("expect latency(method='a') > latency(method='b')", ds, Options{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no such column: latency", err.Error())
+
+	_, err = EvaluateDataset("expect throughput > 1", nil, Options{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: null dataset", err.Error())
+
+	err = CheckDataset("expect throughput(method='c') > throughput(method='b')", ds)
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: value 'c' never occurs in column 'method'", err.Error())
+}
+
+// evaluating a statement in memory gives the same result as in the database
+func TestMemoryDatasetMatchesSQL(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadWorkloadTable(t, db)
+	loadMethodsTable(t, db)
+	loadScalabilityTable(t, db)
+	loadNoisyRunsTable(t, db)
+	loadIncompleteTable(t, db)
+
+	for _, c := range []struct {
+		table     string
+		statement string
+	}{
+		{"workloads", "for size > 1 for each workload, size " +
+			"expect throughput(method='a') > throughput(method='b')"},
+		{"workloads", "expect throughput > 60"},
+		{"methods", "for each size expect throughput(method='a') > throughput(method=*other*)"},
+		{"methods", "rank throughput by method: d > a > b > c"},
+		{"scalability", "for each workload expect speedup(throughput, size) >= 0.8 * size"},
+		{"noisy", "for each size expect cv(throughput(method='a')) < 0.01 " +
+			"excluding outliers by iqr 1.5"},
+		{"noisy", "expect throughput(method='a') > throughput(method='b') * 1.9 " +
+			"excluding outliers by iqr 1.5"},
+		{"incomplete", "expect throughput(method='a') > throughput(method='b')"},
+		{"incomplete", "expect throughput(method='c') > throughput(method='b') on missing skip"},
+	} {
+		expected, err := Evaluate(c.statement, db, c.table)
+		assert.Nil(t, err, c.statement)
+
+		actual, err := EvaluateDataset(c.statement, memoryCopy(t, db, c.table), Options{})
+		assert.Nil(t, err, c.statement)
+		// there's no query for datasets that aren't a table
+		assert.Nil(t, actual.Queries, c.statement)
+		expected.Queries = nil
+		assert.Equal(t, expected, actual, c.statement)
+	}
+}
+
+// repeated measurements of a configuration can't be compared point by point,
+// neither in the database nor in memory
+func TestMemoryDatasetMatchesSQLOnRepetitions(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadNoisyRunsTable(t, db)
+
+	for _, statement := range []string{
+		"expect throughput(method='a') > throughput(method='b')",
+		"expect throughput > 4",
+		"for size=1 expect throughput(method='a') > throughput(method='b') on missing skip",
+	} {
+		_, expected := Evaluate(statement, db, "noisy")
+		assert.NotNil(t, expected, statement)
+		assert.Equal(t, AverError{
+			"number of values for unpredicated columns doesn't match for left/right sides"},
+			expected, statement)
+
+		for _, ds := range []Dataset{
+			memoryCopy(t, db, "noisy"),
+			NewSQLDataset(db, "noisy").Filter(Predicate{"size", ">", 0.0}),
+		} {
+			_, actual := EvaluateDataset(statement, ds, Options{})
+			assert.Equal(t, expected, actual, statement)
+		}
+	}
+}
@@ -5,48 +5,89 @@ package aver
 import (
 	"database/sql"
 	"io/ioutil"
+	"strings"
 
 	sj "github.com/bitly/go-simplejson"
-	"github.com/ivotron/textql"
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// MakeDb loads a file into a table of a database. The file type is either
+// 'config', for a JSON file with the driver, file and table of the database,
+// or a format registered with RegisterFormat, whose
+// data is loaded into a table of an in-memory database.
 func MakeDb(inFile string, fileType string) (db *sql.DB, tblName string, err error) {
-	switch fileType {
-	case "config":
+	if fileType == "config" {
 		return makeDbFromJsonConfig(inFile)
-	case "csv":
-		return makeDbFromCsv(inFile)
-	case "json":
-		return makeDbFromJson(inFile)
-	default:
-		return nil, "", AverError{"Unknown file type " + fileType}
 	}
+	ds, err := LoadDataset(inFile, LoadOptions{Format: fileType})
+	if err != nil {
+		return
+	}
+	return makeDbFromDataset(ds)
 }
 
-func makeDbFromJson(file string) (db *sql.DB, tblName string, err error) {
-	return nil, "", AverError{"JSON not supported yet."}
+// loads a dataset into a table. Metrics become regular columns of the table,
+// which statements take as columns that identify each point unless they refer
+// to them.
+func makeDbFromDataset(ds Dataset) (db *sql.DB, tblName string, err error) {
+	columns, err := ds.Schema()
+	if err != nil {
+		return
+	}
+	rows := make([][]interface{}, 0)
+	err = ds.Scan(columns, func(values []interface{}) error {
+		rows = append(rows, append([]interface{}{}, values...))
+		return nil
+	})
+	if err != nil {
+		return
+	}
+	return makeDbFromRows(columns, rows)
 }
 
-func makeDbFromCsv(file string) (db *sql.DB, tblName string, err error) {
-	delimiter := ","
-	lazyQuotes := false
-	header := true
-	tableName := "tbl"
-	save_to := ""
-	open_sqlite_console := false
-	verbose := false
-
-	// TODO textql.Load logs to stderr/stdout and invokes log.Fatal on error
-	// (which implicitly invokes os.Exit(1)), so we might want to refactor that so
-	// that we can catch and return an error instead
-	db, _, _ = textql.Load(
-		&file, &delimiter, &lazyQuotes, &header, &tableName,
-		&save_to, &open_sqlite_console, &verbose)
+// loads rows into a table of an in-memory sqlite database. Columns have
+// numeric affinity, so values that look like numbers are stored as such, and
+// their names are quoted, since they are taken from the data (e.g. from the
+// header of a CSV file).
+func makeDbFromRows(columns []string, rows [][]interface{}) (db *sql.DB, tblName string, err error) {
+	db, err = sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return
+	}
+	// every connection to an in-memory database gets a database of its own
+	db.SetMaxOpenConns(1)
 
-	return db, tableName, nil
+	tblName = "tbl"
+	definitions := make([]string, len(columns))
+	for i, c := range columns {
+		definitions[i] = SQLite.Quote(c) + " NUMERIC"
+	}
+	if _, err = db.Exec("CREATE TABLE " + tblName + " (" +
+		strings.Join(definitions, ", ") + ")"); err != nil {
+		db.Close()
+		return nil, "", err
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	for _, row := range rows {
+		if _, err = db.Exec(
+			"INSERT INTO "+tblName+" VALUES ("+placeholders+")", row...); err != nil {
+			db.Close()
+			return nil, "", err
+		}
+	}
+	return db, tblName, nil
 }
 
+// opens the database given in a JSON file with the following entries:
+//
+//   - driver: the name of a registered database/sql driver, e.g. 'sqlite3',
+//     'postgres' or 'mysql' (drivers are registered by importing them; the
+//     CLI links the postgres and mysql ones when built with the tags of the
+//     same name)
+//   - dsn: the data source name given to the driver
+//   - table: the table that holds the data
+//
+// The 'sqlite' driver takes the path to the database in a 'file' entry.
 func makeDbFromJsonConfig(dbConfigFile string) (db *sql.DB, tblName string, err error) {
 	b, err := ioutil.ReadFile(dbConfigFile)
 	if err != nil {
@@ -58,13 +99,14 @@ func makeDbFromJsonConfig(dbConfigFile string) (db *sql.DB, tblName string, err
 		return
 	}
 
-	if data, ok := js.CheckGet("driver"); ok {
-		switch data.MustString() {
-		case "sqlite":
-			db, err = makeSqliteDb(js)
-		default:
-			return nil, "", AverError{"Only sqlite is supported. Others coming \"soon\"."}
-		}
+	data, ok := js.CheckGet("driver")
+	if !ok {
+		return nil, "", AverError{"Expecting 'driver' entry in JSON file"}
+	}
+	if driver := data.MustString(); driver == "sqlite" {
+		db, err = makeSqliteDb(js)
+	} else {
+		db, err = makeDriverDb(driver, js)
 	}
 
 	if err != nil {
@@ -75,6 +117,7 @@ func makeDbFromJsonConfig(dbConfigFile string) (db *sql.DB, tblName string, err
 		return db, data.MustString(), nil
 	}
 
+	db.Close()
 	return nil, "", AverError{"Invalid JSON file."}
 }
 
@@ -84,3 +127,18 @@ func makeSqliteDb(js *sj.Json) (db *sql.DB, err error) {
 	}
 	return nil, AverError{"Expecting 'file' entry in JSON file for sqlite driver"}
 }
+
+func makeDriverDb(driver string, js *sj.Json) (db *sql.DB, err error) {
+	registered := false
+	for _, name := range sql.Drivers() {
+		registered = registered || name == driver
+	}
+	if !registered {
+		return nil, AverError{"Unknown driver '" + driver + "'; registered drivers are " +
+			strings.Join(sql.Drivers(), ", ") + " (drivers are registered by importing them)"}
+	}
+	if data, ok := js.CheckGet("dsn"); ok {
+		return sql.Open(driver, data.MustString())
+	}
+	return nil, AverError{"Expecting 'dsn' entry in JSON file for " + driver + " driver"}
+}
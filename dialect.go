@@ -0,0 +1,229 @@
+package aver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Dialect is the SQL flavour of a database, which the queries run against a
+// table of it adjust to (see NewSQLDataset)
+type Dialect interface {
+	// Name of the dialect, e.g. 'postgres'
+	Name() string
+
+	// Quote quotes an identifier, so that it can be a reserved word such as
+	// the 'left' and 'right' aliases of the dependent variable
+	Quote(identifier string) string
+
+	// SchemaQuery returns the query that lists the names of the columns of a
+	// table (one per row, in the order they are defined), which can be
+	// qualified by its schema (e.g. 'results.runs')
+	SchemaQuery(table string) string
+
+	// Join returns the join of two subqueries, aliased 'a' and 'b', on the
+	// given columns, which are the only ones they have in common
+	Join(left string, right string, columns []string) string
+}
+
+// the dialects built in, which cover SQLite, Postgres, MySQL and DuckDB
+var (
+	// SQLite quotes identifiers with double quotes, lists columns with the
+	// table_info pragma and joins with 'natural join'
+	SQLite Dialect = sqliteDialect{}
+
+	// Postgres quotes identifiers with double quotes, lists columns with
+	// information_schema (in the current schema by default, folding the name
+	// of the table to lower case as Postgres does) and joins with 'natural
+	// join'
+	Postgres Dialect = informationSchemaDialect{"postgres", `"`, "current_schema()", true}
+
+	// MySQL quotes identifiers with backticks (double-quoted strings are string
+	// literals unless ANSI_QUOTES is set), lists columns with
+	// information_schema (in the current database by default) and joins with
+	// 'natural join'
+	MySQL Dialect = informationSchemaDialect{"mysql", "`", "database()", false}
+
+	// DuckDB quotes identifiers with double quotes, lists columns with
+	// information_schema (in the current schema by default) and joins with
+	// 'natural join'
+	DuckDB Dialect = informationSchemaDialect{"duckdb", `"`, "current_schema()", false}
+
+	// Standard sticks to standard SQL, for databases whose dialect isn't
+	// known: it quotes identifiers with double quotes, lists columns with
+	// information_schema and joins with 'join ... on', since not every database
+	// supports 'natural join'
+	Standard Dialect = standardDialect{}
+)
+
+var (
+	dialectsMu sync.RWMutex
+
+	// dialects by the type of the database/sql driver
+	driverDialects = map[string]Dialect{
+		"*sqlite3.SQLiteDriver": SQLite,
+		"*sqlite.Driver":        SQLite,
+		"*pq.Driver":            Postgres,
+		"*stdlib.Driver":        Postgres,
+		"*mysql.MySQLDriver":    MySQL,
+		"duckdb.Driver":         DuckDB,
+		"*duckdb.Driver":        DuckDB,
+	}
+)
+
+// RegisterDialect sets the dialect of the databases opened with the given
+// driver, for drivers other than those of SQLite (mattn/go-sqlite3 and
+// modernc.org/sqlite), Postgres (lib/pq and pgx), MySQL
+// (go-sql-driver/mysql) and DuckDB (marcboeker/go-duckdb). Databases opened
+// with drivers of unknown dialect are taken to use Standard.
+func RegisterDialect(drv driver.Driver, d Dialect) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+
+	if drv == nil || d == nil {
+		panic("aver: RegisterDialect driver or dialect is nil")
+	}
+	driverDialects[fmt.Sprintf("%T", drv)] = d
+}
+
+// obtains the dialect of a database from its driver
+func dialectOf(db *sql.DB) Dialect {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+
+	if d, ok := driverDialects[fmt.Sprintf("%T", db.Driver())]; ok {
+		return d
+	}
+	return Standard
+}
+
+// quotes a string literal
+func quoteLiteral(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+// quotes each of the given identifiers, separating them with commas
+func quoteList(d Dialect, identifiers []string) string {
+	quoted := make([]string, len(identifiers))
+	for i, identifier := range identifiers {
+		quoted[i] = d.Quote(identifier)
+	}
+	return strings.Join(quoted, ",")
+}
+
+// renders a conjunction of predicates in SQL, quoting the columns
+func sqlConjunction(d Dialect, predicates []predicate) string {
+	terms := make([]string, len(predicates))
+	for i, p := range predicates {
+		terms[i] = d.Quote(p.column) + p.op + p.sqlLiteral()
+	}
+	return strings.Join(terms, " and ")
+}
+
+func quoteWith(quote string, identifier string) string {
+	return quote + strings.Replace(identifier, quote, quote+quote, -1) + quote
+}
+
+// splits a table name qualified by its schema (if it is)
+func splitTable(table string) (schema string, name string) {
+	if i := strings.LastIndex(table, "."); i >= 0 {
+		return table[:i], table[i+1:]
+	}
+	return "", table
+}
+
+func naturalJoin(left string, right string) string {
+	return left + " as a natural join " + right + " as b"
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string {
+	return "sqlite"
+}
+
+func (sqliteDialect) Quote(identifier string) string {
+	return quoteWith(`"`, identifier)
+}
+
+func (sqliteDialect) SchemaQuery(table string) string {
+	schema, name := splitTable(table)
+	if schema == "" {
+		return "select name from pragma_table_info(" + quoteLiteral(name) + ") order by cid"
+	}
+	return "select name from pragma_table_info(" + quoteLiteral(name) + ", " +
+		quoteLiteral(schema) + ") order by cid"
+}
+
+func (sqliteDialect) Join(left string, right string, columns []string) string {
+	return naturalJoin(left, right)
+}
+
+// a dialect that lists columns with information_schema, in the schema given by
+// the current expression when the table isn't qualified by a schema. Names of
+// tables are folded to lower case if fold is set.
+type informationSchemaDialect struct {
+	name    string
+	quote   string
+	current string
+	fold    bool
+}
+
+func (d informationSchemaDialect) Name() string {
+	return d.name
+}
+
+func (d informationSchemaDialect) Quote(identifier string) string {
+	return quoteWith(d.quote, identifier)
+}
+
+func (d informationSchemaDialect) SchemaQuery(table string) string {
+	if d.fold {
+		table = strings.ToLower(table)
+	}
+	schema, name := splitTable(table)
+	current := d.current
+	if schema != "" {
+		current = quoteLiteral(schema)
+	}
+	return "select column_name from information_schema.columns " +
+		"where table_schema = " + current + " and table_name = " + quoteLiteral(name) +
+		" order by ordinal_position"
+}
+
+func (d informationSchemaDialect) Join(left string, right string, columns []string) string {
+	return naturalJoin(left, right)
+}
+
+type standardDialect struct{}
+
+func (standardDialect) Name() string {
+	return "standard"
+}
+
+func (standardDialect) Quote(identifier string) string {
+	return quoteWith(`"`, identifier)
+}
+
+func (standardDialect) SchemaQuery(table string) string {
+	schema, name := splitTable(table)
+	query := "select column_name from information_schema.columns " +
+		"where table_name = " + quoteLiteral(name)
+	if schema != "" {
+		query += " and table_schema = " + quoteLiteral(schema)
+	}
+	return query + " order by ordinal_position"
+}
+
+func (d standardDialect) Join(left string, right string, columns []string) string {
+	on := make([]string, len(columns))
+	for i, column := range columns {
+		on[i] = "a." + d.Quote(column) + " = b." + d.Quote(column)
+	}
+	if len(on) == 0 {
+		on = []string{"1 = 1"}
+	}
+	return left + " as a join " + right + " as b on " + strings.Join(on, " and ")
+}
@@ -0,0 +1,411 @@
+package aver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+// emulates the dialect of another database on top of SQLite: queries are
+// checked against the rules of the dialect that SQLite is lenient about, and
+// run by SQLite if they follow them. Queries on information_schema are run on
+// the table_info pragma instead.
+type emulatedDriver struct {
+	// the character that quotes identifiers
+	quote string
+
+	// whether the database supports 'natural join'
+	natural bool
+}
+
+type postgresEmulation struct{ emulatedDriver }
+type mysqlEmulation struct{ emulatedDriver }
+type duckdbEmulation struct{ emulatedDriver }
+type standardEmulation struct{ emulatedDriver }
+
+func init() {
+	sql.Register("sqlite3-postgres", &postgresEmulation{emulatedDriver{`"`, true}})
+	sql.Register("sqlite3-mysql", &mysqlEmulation{emulatedDriver{"`", true}})
+	sql.Register("sqlite3-duckdb", &duckdbEmulation{emulatedDriver{`"`, true}})
+	sql.Register("sqlite3-standard", &standardEmulation{emulatedDriver{`"`, false}})
+	RegisterDialect(&postgresEmulation{}, Postgres)
+	RegisterDialect(&mysqlEmulation{}, MySQL)
+	RegisterDialect(&duckdbEmulation{}, DuckDB)
+}
+
+func (d emulatedDriver) Open(dsn string) (driver.Conn, error) {
+	c, err := (&sqlite3.SQLiteDriver{}).Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return emulatedConn{c, d}, nil
+}
+
+// only implements driver.Conn, so that every query is prepared
+type emulatedConn struct {
+	driver.Conn
+	d emulatedDriver
+}
+
+func (c emulatedConn) Prepare(query string) (driver.Stmt, error) {
+	if err := c.d.check(query); err != nil {
+		return nil, err
+	}
+	if m := rxInformationSchema.FindStringSubmatch(query); m != nil {
+		query = "select name from pragma_table_info(" + m[1] + ") order by cid"
+	}
+	return c.Conn.Prepare(query)
+}
+
+var (
+	rxInformationSchema = regexp.MustCompile(`^select column_name from information_schema\.columns ` +
+		`where .*\btable_name = ('(?:[^']|'')*').* order by ordinal_position$`)
+	rxNatural  = regexp.MustCompile(`(?i)\bnatural\s+join\b`)
+	rxPragma   = regexp.MustCompile(`(?i)\bpragma`)
+	rxReserved = regexp.MustCompile(`(?i)\b(left|right)\b(\s+(outer\s+)?join\b)?`)
+	rxSubquery = regexp.MustCompile(`(?i)^\(\s*select\b`)
+	rxAlias    = regexp.MustCompile(`(?i)^\s*(\w+)`)
+)
+
+func (d emulatedDriver) check(query string) error {
+	// blank out literals and quoted identifiers
+	stripped := []byte(query)
+	quote := byte(0)
+	for i := 0; i < len(stripped); i++ {
+		c := stripped[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+			stripped[i] = ' '
+		case c == '\'' || c == d.quote[0]:
+			quote = c
+			stripped[i] = ' '
+		case c == '"' || c == '`':
+			return emulationError{"unexpected quote " + string(c), query}
+		}
+	}
+	s := string(stripped)
+
+	if rxPragma.MatchString(s) {
+		return emulationError{"relation pragma_table_info does not exist", query}
+	}
+	if !d.natural && rxNatural.MatchString(s) {
+		return emulationError{"syntax error at or near 'natural'", query}
+	}
+	for _, m := range rxReserved.FindAllStringSubmatch(s, -1) {
+		if m[2] == "" {
+			return emulationError{"syntax error at or near '" + m[1] + "'", query}
+		}
+	}
+	for i := range s {
+		if !rxSubquery.MatchString(s[i:]) {
+			continue
+		}
+		depth, end := 0, i
+		for j := i; j < len(s); j++ {
+			if s[j] == '(' {
+				depth++
+			} else if s[j] == ')' {
+				if depth--; depth == 0 {
+					end = j
+					break
+				}
+			}
+		}
+		m := rxAlias.FindStringSubmatch(s[end+1:])
+		if m == nil {
+			return emulationError{"subquery in FROM must have an alias", query}
+		}
+		switch strings.ToLower(m[1]) {
+		case "natural", "join", "where", "on", "using", "inner", "cross", "group", "order":
+			return emulationError{"subquery in FROM must have an alias", query}
+		}
+	}
+	return nil
+}
+
+type emulationError struct {
+	msg   string
+	query string
+}
+
+func (e emulationError) Error() string {
+	return e.msg + ": " + e.query
+}
+
+func TestEmulatedDialectChecks(t *testing.T) {
+	postgres := emulatedDriver{`"`, true}
+	mysql := emulatedDriver{"`", true}
+	standard := emulatedDriver{`"`, false}
+
+	for _, d := range []emulatedDriver{postgres, mysql, standard} {
+		assert.NotNil(t, d.check("select x as left from t"))
+		assert.NotNil(t, d.check("select count(*) from ((select x from t) natural join (select x from t))"))
+		assert.Nil(t, d.check("select a.x from t as a left join t as b on a.x = b.x where a.y = 'left'"))
+		assert.Nil(t, d.check("select count(*) from ((select x from t) as a join (select x from t) b on a.x = b.x)"))
+		assert.Nil(t, d.check("SELECT * FROM t LIMIT 1"))
+		assert.NotNil(t, d.check("select name from pragma_table_info('t')"))
+	}
+	assert.Nil(t, postgres.check("select count(*) from ((select x from t) as a natural join (select x from t) b)"))
+	assert.NotNil(t, standard.check("select count(*) from ((select x from t) as a natural join (select x from t) b)"))
+	assert.Nil(t, postgres.check(`select x as "left" from t`))
+	assert.NotNil(t, postgres.check("select x as `left` from t"))
+	assert.Nil(t, mysql.check("select x as `left` from t"))
+	assert.NotNil(t, mysql.check(`select x as "left" from t`))
+}
+
+func TestDbFromDriverConfig(t *testing.T) {
+	path, err := ioutil.TempDir("", "aver")
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(path))
+
+	db := openDB(t, "temp.db")
+	loadTestTable(t, db)
+	db.Close()
+
+	for _, driver := range []string{
+		"sqlite3", "sqlite3-postgres", "sqlite3-mysql", "sqlite3-duckdb", "sqlite3-standard"} {
+		js := []byte(`{
+			"driver": "` + driver + `",
+			"dsn": "temp.db",
+			"table": "metrics"
+		}`)
+		assert.Nil(t, ioutil.WriteFile("config.json", js, 0644))
+
+		db, tblName, err := MakeDb("config.json", "config")
+		assert.Nil(t, err)
+		assert.Equal(t, "metrics", tblName)
+
+		validate(t, db, tblName)
+
+		r, err := Evaluate(
+			"for size > 3 expect throughput(method='ceph') > throughput(method='raw')", db, tblName)
+		assert.Nil(t, err, driver)
+		assert.False(t, r.Holds)
+		assert.Equal(t, 3, len(r.Counterexamples))
+
+		r, err = Evaluate("expect throughput < 60", db, tblName)
+		assert.Nil(t, err, driver)
+		assert.True(t, r.Holds)
+		db.Close()
+	}
+
+	assert.Nil(t, ioutil.WriteFile("config.json", []byte(`{
+		"driver": "nosuchdriver",
+		"dsn": "temp.db",
+		"table": "metrics"
+	}`), 0644))
+	_, _, err = MakeDb("config.json", "config")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "aver: Unknown driver 'nosuchdriver'; registered drivers are ")
+
+	assert.Nil(t, ioutil.WriteFile("config.json", []byte(`{
+		"driver": "sqlite3-postgres",
+		"table": "metrics"
+	}`), 0644))
+	_, _, err = MakeDb("config.json", "config")
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: Expecting 'dsn' entry in JSON file for sqlite3-postgres driver",
+		err.Error())
+}
+
+func TestDialectOf(t *testing.T) {
+	for driver, d := range map[string]Dialect{
+		"sqlite3":          SQLite,
+		"sqlite3-postgres": Postgres,
+		"sqlite3-mysql":    MySQL,
+		"sqlite3-duckdb":   DuckDB,
+		"sqlite3-standard": Standard,
+	} {
+		db, err := sql.Open(driver, ":memory:")
+		assert.Nil(t, err)
+		assert.Equal(t, d, dialectOf(db), driver)
+		db.Close()
+	}
+
+	assert.Panics(t, func() { RegisterDialect(nil, SQLite) })
+	assert.Panics(t, func() { RegisterDialect(&standardEmulation{}, nil) })
+}
+
+func TestSQLDatasetSchema(t *testing.T) {
+	for _, driver := range []string{"sqlite3", "sqlite3-postgres", "sqlite3-mysql", "sqlite3-standard"} {
+		db, err := sql.Open(driver, ":memory:")
+		assert.Nil(t, err)
+		db.SetMaxOpenConns(1)
+		loadTestTable(t, db)
+
+		columns, err := NewSQLDataset(db, "metrics").Schema()
+		assert.Nil(t, err, driver)
+		assert.Equal(t, []string{"size", "replication", "method", "throughput"}, columns)
+
+		_, err = NewSQLDataset(db, "nosuchtable").Schema()
+		assert.NotNil(t, err)
+		assert.Equal(t, "aver: no such table: nosuchtable", err.Error())
+		db.Close()
+	}
+
+	// the dialect can be given regardless of the driver
+	db, err := sql.Open("sqlite3-postgres", ":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	loadTestTable(t, db)
+	r, err := EvaluateDataset("expect throughput(method='ceph') < throughput(method='raw')",
+		NewSQLDatasetWithDialect(db, "metrics", Standard), Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Contains(t, r.Queries[0], ") as a join ")
+}
+
+func TestDialectQueries(t *testing.T) {
+	v, err := ParseValidation(
+		"for size > 3 expect throughput(method='ceph') > throughput(method='raw') * 0.9")
+	assert.Nil(t, err)
+	columns := []string{"size", "replication"}
+
+	// queries are written with double quotes, which MySQL's take the place of
+	backticks := func(query string) string {
+		return strings.Replace(query, `"`, "`", -1)
+	}
+	left := `(select "size","replication" from metrics where "method"='ceph' and "size">3)`
+	right := `(select "size","replication" from metrics where "method"='raw' and "size">3)`
+	naturalJoin := "select count(*) from (" + left + " as a natural join " + right + " as b)"
+	naturalQuery := `select count(*) from ( ` +
+		`  (select "size","replication","throughput" as "left"      ` +
+		`from metrics where "method"='ceph' and "size">3  ) as a natural join ` +
+		`  (select "size","replication","throughput" as "right"      ` +
+		`from metrics where "method"='raw' and "size">3  ) as b) ` +
+		`where "left" > "right"  * 0.9`
+
+	tests := []struct {
+		d         Dialect
+		name      string
+		schema    string
+		qualified string
+		join      string
+		query     string
+	}{
+		{SQLite, "sqlite",
+			"select name from pragma_table_info('metrics') order by cid",
+			"select name from pragma_table_info('Metrics', 'results') order by cid",
+			naturalJoin,
+			naturalQuery,
+		},
+		{Postgres, "postgres",
+			"select column_name from information_schema.columns " +
+				"where table_schema = current_schema() and table_name = 'metrics' order by ordinal_position",
+			"select column_name from information_schema.columns " +
+				"where table_schema = 'results' and table_name = 'metrics' order by ordinal_position",
+			naturalJoin,
+			naturalQuery,
+		},
+		{MySQL, "mysql",
+			"select column_name from information_schema.columns " +
+				"where table_schema = database() and table_name = 'metrics' order by ordinal_position",
+			"select column_name from information_schema.columns " +
+				"where table_schema = 'results' and table_name = 'Metrics' order by ordinal_position",
+			backticks(naturalJoin),
+			backticks(naturalQuery),
+		},
+		{DuckDB, "duckdb",
+			"select column_name from information_schema.columns " +
+				"where table_schema = current_schema() and table_name = 'metrics' order by ordinal_position",
+			"select column_name from information_schema.columns " +
+				"where table_schema = 'results' and table_name = 'Metrics' order by ordinal_position",
+			naturalJoin,
+			naturalQuery,
+		},
+		{Standard, "standard",
+			"select column_name from information_schema.columns " +
+				"where table_name = 'metrics' order by ordinal_position",
+			"select column_name from information_schema.columns " +
+				"where table_name = 'Metrics' and table_schema = 'results' order by ordinal_position",
+			"select count(*) from (" + left + " as a join " + right + " as b " +
+				`on a."size" = b."size" and a."replication" = b."replication")`,
+			`select count(*) from ( ` +
+				`  (select "size","replication","throughput" as "left"      ` +
+				`from metrics where "method"='ceph' and "size">3  ) as a join ` +
+				`  (select "size","replication","throughput" as "right"      ` +
+				`from metrics where "method"='raw' and "size">3  ) as b ` +
+				`on a."size" = b."size" and a."replication" = b."replication") ` +
+				`where "left" > "right"  * 0.9`,
+		},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.name, test.d.Name())
+		assert.Equal(t, test.schema, test.d.SchemaQuery("metrics"), test.name)
+		assert.Equal(t, test.qualified, test.d.SchemaQuery("results.Metrics"), test.name)
+		assert.Equal(t, test.join, v.joinQuery(test.d, "metrics", columns), test.name)
+		assert.Equal(t, test.query, v.query(test.d, "metrics", columns, false), test.name)
+	}
+
+	// names of columns that aren't identifiers, or that are reserved words
+	columns = []string{"ns/op", "my col", "order"}
+	left = `(select "ns/op","my col","order" from metrics where "method"='ceph' and "size">3)`
+	right = `(select "ns/op","my col","order" from metrics where "method"='raw' and "size">3)`
+	for _, d := range []Dialect{SQLite, Postgres, DuckDB} {
+		assert.Equal(t, "select count(*) from ("+left+" as a natural join "+right+" as b)",
+			v.joinQuery(d, "metrics", columns), d.Name())
+	}
+	assert.Equal(t, backticks("select count(*) from ("+left+" as a natural join "+right+" as b)"),
+		v.joinQuery(MySQL, "metrics", columns))
+	assert.Equal(t, "select count(*) from ("+left+" as a join "+right+" as b "+
+		`on a."ns/op" = b."ns/op" and a."my col" = b."my col" and a."order" = b."order")`,
+		v.joinQuery(Standard, "metrics", columns))
+	assert.Equal(t, `select count(*) from ( `+
+		"  (select `ns/op`,`my col`,`order`,`throughput` as `left`      "+
+		"from metrics where `method`='ceph' and `size`>3  ) as a natural join "+
+		"  (select `ns/op`,`my col`,`order`,`throughput` as `right`      "+
+		"from metrics where `method`='raw' and `size`>3  ) as b) "+
+		"where `left` > `right`  * 0.9",
+		v.query(MySQL, "metrics", columns, false))
+
+	assert.Equal(t, `"a""b"`, SQLite.Quote(`a"b`))
+	assert.Equal(t, "`a``b`", MySQL.Quote("a`b"))
+}
+
+func TestQuotedColumns(t *testing.T) {
+	path, err := ioutil.TempDir("", "aver")
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(path))
+
+	data := []byte(`ns/op,my col,order,method,throughput
+1,a,1,raw,58
+1,a,1,ceph,55.9
+2,b,2,raw,58
+2,b,2,ceph,50.1
+`)
+	assert.Nil(t, ioutil.WriteFile("data.csv", data, 0644))
+
+	db, tblName, err := MakeDb("data.csv", "csv")
+	assert.Nil(t, err)
+	defer db.Close()
+
+	columns, err := NewSQLDataset(db, tblName).Schema()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ns/op", "my col", "order", "method", "throughput"}, columns)
+
+	r, err := Evaluate(
+		"expect throughput(method='ceph') > throughput(method='raw') * 0.9", db, tblName)
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []PointResult{
+		{"", "method='ceph' and ns/op=2 and my col='b' and order=2", 50.1, 58 * 0.9, false},
+	}, r.Counterexamples)
+
+	r, err = EvaluateDataset(
+		"expect throughput(method='ceph') > throughput(method='raw') * 0.85",
+		NewSQLDataset(db, tblName).Filter(Predicate{"order", ">", 0.0}),
+		Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+}
@@ -0,0 +1,91 @@
+package aver
+
+import (
+	"strconv"
+)
+
+// DistributionResult is the outcome of a distribution statement, i.e. one
+// that compares the values of each side as a whole instead of pairwise
+type DistributionResult struct {
+	// Group is the 'for each' group the result belongs to (if any)
+	Group string
+	// Left and Right are the predicates that select the values of each side
+	Left  string
+	Right string
+	// Test is either 'dominates' (first-order stochastic dominance) or 'ks'
+	// (two-sample Kolmogorov-Smirnov test)
+	Test string
+	// Statistic is, for 'ks', the D statistic; for 'dominates', the largest
+	// amount by which the empirical CDF of the left side exceeds the one of
+	// the right side (0 if the left side dominates)
+	Statistic float64
+	// PValue of the KS test (only for 'ks')
+	PValue     float64
+	LeftCount  int
+	RightCount int
+	Holds      bool
+}
+
+// evaluates a distribution statement, taking the values of each side from the
+// rows selected by the global predicates and the side's predicates
+func (v Validation) compareDistributions(ds Dataset) (
+	r DistributionResult, err error) {
+
+	if v.left.funcName != v.right.funcName {
+		return r, AverError{
+			"Validation comparison; " + v.left.funcName + " distinct to " + v.right.funcName}
+	}
+
+	r = DistributionResult{
+		Left: v.left.predicates, Right: v.right.predicates, Test: v.distribution}
+
+	left, err := selectValues(ds, v.left.funcName, v.left.terms, v.globalTerms)
+	if err != nil {
+		return
+	}
+	if len(left) == 0 {
+		return r, AverError{"no values associated to left-side predicates"}
+	}
+	right, err := selectValues(ds, v.right.funcName, v.right.terms, v.globalTerms)
+	if err != nil {
+		return
+	}
+	if len(right) == 0 {
+		return r, AverError{"no values associated to right-side predicates"}
+	}
+	r.LeftCount, r.RightCount = len(left), len(right)
+
+	switch v.distribution {
+	case "dominates":
+		r.Holds, r.Statistic = dominates(left, right)
+	case "ks":
+		alpha := 0.05
+		if v.significance != "" {
+			if alpha, err = strconv.ParseFloat(v.significance, 64); err != nil {
+				return r, AverError{"Expecting numeric significance level; got " + v.significance}
+			}
+		}
+		r.Statistic, r.PValue = ksTest(left, right)
+		// the distributions are taken to be the same unless the test rejects
+		// the null hypothesis at the given significance level
+		r.Holds = r.PValue >= alpha
+	default:
+		return r, AverError{"unknown distribution test " + v.distribution}
+	}
+	return
+}
+
+// obtains the (non-missing) values of a column for the rows satisfying the
+// given conjunctions
+func selectValues(ds Dataset, column string, conjunctions ...[]predicate) (
+	values []float64, err error) {
+
+	points, err := selectPoints(ds, nil, []string{column}, conjunctions...)
+	if err != nil {
+		return
+	}
+	for _, p := range present(points) {
+		values = append(values, p.values[0])
+	}
+	return
+}
@@ -0,0 +1,92 @@
+package aver
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadLatencyTable(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(`
+		CREATE TABLE latencies (
+			method VARCHAR(255),
+			latency FLOAT
+		)
+	`)
+	assert.Nil(t, err)
+
+	for i := 0; i < 20; i++ {
+		_, err = db.Exec(fmt.Sprintf(
+			"INSERT INTO latencies VALUES('a', %d), ('b', %d), ('c', %d)",
+			20+i, 10+i, 11+i))
+		assert.Nil(t, err)
+	}
+}
+
+func TestDominates(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadLatencyTable(t, db)
+
+	r, err := Evaluate(
+		"expect latency(method='a') dominates latency(method='b')", db, "latencies")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 1, len(r.Distributions))
+	assert.Equal(t, "dominates", r.Distributions[0].Test)
+	assert.Equal(t, 20, r.Distributions[0].LeftCount)
+	assert.Equal(t, 20, r.Distributions[0].RightCount)
+
+	r, err = Evaluate(
+		"expect latency(method='b') dominates latency(method='a')", db, "latencies")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, 0.5, r.Distributions[0].Statistic)
+
+	r, err = Evaluate(
+		"expect latency(method='a') dominates latency(method=*other*)", db, "latencies")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 2, len(r.Pairs))
+	assert.Equal(t, 2, len(r.Distributions))
+}
+
+func TestSameDistribution(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadLatencyTable(t, db)
+
+	r, err := Evaluate(`
+	expect
+	  latency(method='b') same distribution as latency(method='c') at 0.05
+	`, db, "latencies")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, "ks", r.Distributions[0].Test)
+	assert.InDelta(t, 0.05, r.Distributions[0].Statistic, 1e-9)
+	assert.True(t, r.Distributions[0].PValue > 0.05)
+
+	holds, err := Holds(`
+	expect
+	  latency(method='b') same distribution as latency(method='a')
+	`, db, "latencies")
+
+	assert.Nil(t, err)
+	assert.False(t, holds)
+
+	_, err = Holds(`
+	expect
+	  latency(method='b') same distribution as latency(method='d')
+	`, db, "latencies")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no values associated to right-side predicates", err.Error())
+}
@@ -0,0 +1,141 @@
+package aver
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	sj "github.com/bitly/go-simplejson"
+)
+
+// NewFioDataset reads the output of 'fio --output-format=json' into a Dataset
+// held in memory, with one row for each job and direction of I/O (read, write
+// or trim) that the job did. Columns are:
+//
+//   - jobname and groupid, which are told as metrics (see MetricDataset) so that
+//     jobs are compared by their options
+//   - one for each option of the job, including global options, e.g. rw, bs,
+//     iodepth or numjobs
+//   - direction: 'read', 'write' or 'trim'
+//   - metrics for the direction: iops, bw_kib_per_s (bandwidth in KiB/s),
+//     io_bytes, runtime_ms, and lat_ns_min, lat_ns_max, lat_ns_mean and
+//     lat_ns_stddev for the total latency (likewise clat_ns_* for completion
+//     and slat_ns_* for submission latency), with completion latency
+//     percentiles as clat_ns_p50, clat_ns_p99, clat_ns_p99_9 and so on
+//   - metrics for the job: usr_cpu, sys_cpu and ctx
+//
+// Options given as numbers (e.g. iodepth=32) are numeric; the rest (e.g.
+// bs=4k) are strings.
+func NewFioDataset(r io.Reader) (Dataset, error) {
+	js, err := sj.NewFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := js.Get("jobs").Array()
+	if err != nil {
+		return nil, AverError{"expecting 'jobs' array in fio output"}
+	}
+	global := js.Get("global options").MustMap()
+
+	var t tableBuilder
+	metrics := []string{"jobname", "groupid"}
+	metric := func(name string) int {
+		if !t.has(name) {
+			metrics = append(metrics, name)
+		}
+		return t.column(name)
+	}
+
+	for i := range jobs {
+		job := js.Get("jobs").GetIndex(i)
+		for _, direction := range []string{"read", "write", "trim"} {
+			stats, ok := job.CheckGet(direction)
+			if !ok || stats.Get("io_bytes").MustInt64() == 0 {
+				continue
+			}
+			values := make(map[int]interface{})
+			values[t.column("jobname")] = job.Get("jobname").MustString()
+			values[t.column("groupid")] = jsonValue(job.Get("groupid").Interface())
+			options := make(map[string]interface{})
+			for key, value := range global {
+				options[key] = value
+			}
+			for key, value := range job.Get("job options").MustMap() {
+				options[key] = value
+			}
+			for _, key := range sortedNames(options) {
+				values[t.column(columnName(key))] = jsonValue(options[key])
+			}
+			values[t.column("direction")] = direction
+
+			values[metric("iops")] = jsonValue(stats.Get("iops").Interface())
+			values[metric("bw_kib_per_s")] = jsonValue(stats.Get("bw").Interface())
+			values[metric("io_bytes")] = jsonValue(stats.Get("io_bytes").Interface())
+			values[metric("runtime_ms")] = jsonValue(stats.Get("runtime").Interface())
+			for _, lat := range []string{"lat_ns", "clat_ns", "slat_ns"} {
+				for _, stat := range []string{"min", "max", "mean", "stddev"} {
+					if v, ok := stats.Get(lat).CheckGet(stat); ok {
+						values[metric(lat+"_"+stat)] = jsonValue(v.Interface())
+					}
+				}
+			}
+			percentiles := stats.Get("clat_ns").Get("percentile").MustMap()
+			for _, p := range sortedPercentiles(percentiles) {
+				values[metric("clat_ns_p"+percentileName(p))] = jsonValue(percentiles[p])
+			}
+			for _, key := range []string{"usr_cpu", "sys_cpu", "ctx"} {
+				if v, ok := job.CheckGet(key); ok {
+					values[metric(key)] = jsonValue(v.Interface())
+				}
+			}
+			t.add(values)
+		}
+	}
+	if len(t.rows) == 0 {
+		return nil, AverError{"no fio jobs with I/O found"}
+	}
+
+	columns, rows := t.table()
+	ds, err := NewMemoryDataset(columns, rows)
+	if err != nil {
+		return nil, err
+	}
+	return WithMetrics(ds, metrics...), nil
+}
+
+// percentiles (e.g. '99.900000') in increasing order
+func sortedPercentiles(percentiles map[string]interface{}) []string {
+	keys := make([]string, 0, len(percentiles))
+	for k := range percentiles {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, _ := strconv.ParseFloat(keys[i], 64)
+		b, _ := strconv.ParseFloat(keys[j], 64)
+		return a < b
+	})
+	return keys
+}
+
+// e.g. '99_9' for '99.900000'
+func percentileName(p string) string {
+	if strings.Contains(p, ".") {
+		p = strings.TrimRight(strings.TrimRight(p, "0"), ".")
+	}
+	return strings.Replace(p, ".", "_", -1)
+}
+
+// converts a value decoded from JSON, where numbers (and strings holding
+// numbers) become numeric
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		return csvValue(v.String())
+	case string:
+		return csvValue(v)
+	default:
+		return v
+	}
+}
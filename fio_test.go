@@ -0,0 +1,115 @@
+package aver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const fioOutput = `{
+  "fio version" : "fio-3.28",
+  "timestamp" : 1700000000,
+  "global options" : {
+    "ioengine" : "libaio",
+    "direct" : "1",
+    "bs" : "4k"
+  },
+  "jobs" : [
+    {
+      "jobname" : "randread",
+      "groupid" : 0,
+      "error" : 0,
+      "job options" : {
+        "rw" : "randread",
+        "iodepth" : "32"
+      },
+      "read" : {
+        "io_bytes" : 1073741824,
+        "bw" : 104857,
+        "iops" : 26214.4,
+        "runtime" : 10000,
+        "slat_ns" : {"min" : 1000, "max" : 50000, "mean" : 2100.5, "stddev" : 300.1},
+        "clat_ns" : {
+          "min" : 80000, "max" : 900000, "mean" : 120000.2, "stddev" : 20000.3,
+          "percentile" : {"50.000000" : 117000, "99.000000" : 250000, "99.900000" : 410000}
+        },
+        "lat_ns" : {"min" : 81000, "max" : 910000, "mean" : 122100.7, "stddev" : 20100.4}
+      },
+      "write" : {"io_bytes" : 0, "bw" : 0, "iops" : 0.0, "runtime" : 0},
+      "trim" : {"io_bytes" : 0, "bw" : 0, "iops" : 0.0, "runtime" : 0},
+      "usr_cpu" : 8.5,
+      "sys_cpu" : 30.2,
+      "ctx" : 250000
+    },
+    {
+      "jobname" : "randrw",
+      "groupid" : 1,
+      "error" : 0,
+      "job options" : {
+        "rw" : "randrw",
+        "iodepth" : "32",
+        "bs" : "4k"
+      },
+      "read" : {
+        "io_bytes" : 536870912,
+        "bw" : 52428,
+        "iops" : 6553.6,
+        "runtime" : 10000,
+        "clat_ns" : {"min" : 90000, "max" : 990000, "mean" : 150000.0, "stddev" : 25000.0}
+      },
+      "write" : {
+        "io_bytes" : 536870912,
+        "bw" : 52428,
+        "iops" : 6553.6,
+        "runtime" : 10000,
+        "clat_ns" : {"min" : 95000, "max" : 999000, "mean" : 160000.0, "stddev" : 26000.0}
+      },
+      "usr_cpu" : 6.1,
+      "sys_cpu" : 25.0,
+      "ctx" : 120000
+    }
+  ]
+}`
+
+func TestFioDataset(t *testing.T) {
+	ds, err := NewFioDataset(strings.NewReader(fioOutput))
+	assert.Nil(t, err)
+
+	columns, err := ds.Schema()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"jobname", "groupid", "bs", "direct", "iodepth", "ioengine", "rw", "direction",
+		"iops", "bw_kib_per_s", "io_bytes", "runtime_ms",
+		"lat_ns_min", "lat_ns_max", "lat_ns_mean", "lat_ns_stddev",
+		"clat_ns_min", "clat_ns_max", "clat_ns_mean", "clat_ns_stddev",
+		"slat_ns_min", "slat_ns_max", "slat_ns_mean", "slat_ns_stddev",
+		"clat_ns_p50", "clat_ns_p99", "clat_ns_p99_9", "usr_cpu", "sys_cpu", "ctx",
+	}, columns)
+
+	rows := make([][]interface{}, 0)
+	err = ds.Scan([]string{"jobname", "bs", "iodepth", "direction", "iops", "clat_ns_p99_9"},
+		func(values []interface{}) error {
+			rows = append(rows, values)
+			return nil
+		})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{
+		{"randread", "4k", int64(32), "read", 26214.4, int64(410000)},
+		{"randrw", "4k", int64(32), "read", 6553.6, nil},
+		{"randrw", "4k", int64(32), "write", 6553.6, nil},
+	}, rows)
+
+	r, err := EvaluateDataset(
+		"for direction='read' expect iops(rw='randread') > iops(rw='randrw') * 2", ds, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+
+	_, err = NewFioDataset(strings.NewReader(`{"fio version" : "fio-3.28"}`))
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: expecting 'jobs' array in fio output", err.Error())
+
+	_, err = NewFioDataset(strings.NewReader(`{"jobs" : []}`))
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no fio jobs with I/O found", err.Error())
+}
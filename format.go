@@ -0,0 +1,200 @@
+package aver
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// LoadOptions are the options for reading a dataset
+type LoadOptions struct {
+	// Format of the data, as registered with RegisterFormat. When empty, it is
+	// detected from the content and the name of the file.
+	Format string
+
+	// File the data is read from, if any
+	File string
+
+	// Settings specific to the format, such as the 'delimiter' of CSV files
+	Settings map[string]string
+
+	// PathTemplate gives columns whose values are taken from the path of each
+	// file read by LoadDatasets, e.g. 'runs/{host}/{date}.csv'
+	PathTemplate string
+}
+
+// Loader reads data of some format into a Dataset
+type Loader func(r io.Reader, opts LoadOptions) (Dataset, error)
+
+type format struct {
+	name       string
+	extensions []string
+	loader     Loader
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   []format
+)
+
+func init() {
+	RegisterFormat("csv", []string{".csv"}, loadCSV)
+	RegisterFormat("gobench", []string{".bench"}, ignoringOptions(NewGoBenchDataset))
+	RegisterFormat("sysbench", nil, ignoringOptions(NewSysbenchDataset))
+	RegisterFormat("hyperfine", []string{".json"}, ignoringOptions(NewHyperfineDataset))
+	RegisterFormat("fio", []string{".json"}, ignoringOptions(NewFioDataset))
+}
+
+// RegisterFormat makes MakeDb, LoadDataset and ReadDataset accept data of the
+// given format, which is read with loader. Files with any of the given
+// extensions (e.g. '.csv') are taken to be of the format when it isn't given.
+// It panics if loader is nil or if the format is taken.
+func RegisterFormat(name string, extensions []string, loader Loader) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	if loader == nil {
+		panic("aver: RegisterFormat loader is nil")
+	}
+	if name == "config" {
+		panic("aver: RegisterFormat called for reserved format " + name)
+	}
+	for _, f := range formats {
+		if f.name == name {
+			panic("aver: RegisterFormat called twice for format " + name)
+		}
+	}
+	formats = append(formats, format{name, extensions, loader})
+}
+
+// Formats returns the names of the registered formats
+func Formats() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = f.name
+	}
+	return names
+}
+
+func ignoringOptions(load func(r io.Reader) (Dataset, error)) Loader {
+	return func(r io.Reader, opts LoadOptions) (Dataset, error) {
+		return load(r)
+	}
+}
+
+func lookupFormat(name string) (format, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	for _, f := range formats {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return format{}, false
+}
+
+// the formats to try on data of an unknown format read from the given file:
+// those with the extension of the file go first, and formats registered later
+// go before those registered earlier, so that they take precedence over the
+// built-in ones (CSV, which almost any text can be read as, goes last)
+func candidateFormats(file string) []format {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	ext := strings.ToLower(filepath.Ext(uncompressedName(file)))
+	matching := make([]format, 0)
+	others := make([]format, 0)
+	for i := len(formats) - 1; i >= 0; i-- {
+		f := formats[i]
+		if ext != "" && hasExtension(f, ext) {
+			matching = append(matching, f)
+		} else {
+			others = append(others, f)
+		}
+	}
+	return append(matching, others...)
+}
+
+func hasExtension(f format, ext string) bool {
+	for _, e := range f.extensions {
+		if strings.ToLower(e) == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadDataset reads a file into a Dataset (see ReadDataset). The file '-'
+// stands for the standard input.
+func LoadDataset(file string, opts LoadOptions) (Dataset, error) {
+	if file == "-" {
+		opts.File = ""
+		return ReadDataset(os.Stdin, opts)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	opts.File = file
+	return ReadDataset(f, opts)
+}
+
+// ReadDataset reads data of the format given in the options. Loaders of the
+// formats that are not CSV tell apart metrics from the columns that identify
+// each point (see MetricDataset). When no format is given, it is the first
+// one whose loader reads the data, trying first those registered with the
+// extension of the file the data is read from (if any, and regardless of
+// extensions of compressed files such as '.gz'). Data compressed with gzip,
+// bzip2 or zstd is decompressed before it's given to the loader.
+func ReadDataset(r io.Reader, opts LoadOptions) (Dataset, error) {
+	var f format
+	if opts.Format != "" {
+		var ok bool
+		if f, ok = lookupFormat(opts.Format); !ok {
+			return nil, AverError{"Unknown file type " + opts.Format}
+		}
+	}
+
+	dr, err := decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	defer dr.Close()
+	r = dr
+
+	if opts.Format != "" {
+		return f.loader(r, opts)
+	}
+
+	// loaders consume their input, so each one gets a copy of it
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var firstErr error
+	for _, f := range candidateFormats(opts.File) {
+		ds, err := f.loader(bytes.NewReader(b), opts)
+		if err == nil {
+			return ds, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	name := opts.File
+	if name == "" {
+		name = "input"
+	}
+	return nil, AverError{"unable to detect the format of " + name + ": " + firstErr.Error()}
+}
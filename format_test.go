@@ -0,0 +1,111 @@
+package aver
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// an in-house format with a 'method: throughput' line per run, which are
+// numbered for each method
+func loadRunLog(r io.Reader, opts LoadOptions) (Dataset, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([][]interface{}, 0)
+	runs := make(map[string]int64)
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		fields := strings.SplitN(line, ": ", 2)
+		if len(fields) != 2 || strings.ContainsAny(fields[0], ", ") {
+			return nil, AverError{"not a run log"}
+		}
+		runs[fields[0]]++
+		rows = append(rows, []interface{}{fields[0], runs[fields[0]], csvValue(fields[1])})
+	}
+	ds, err := NewMemoryDataset([]string{"method", "run", "throughput"}, rows)
+	if err != nil {
+		return nil, err
+	}
+	return WithRuns(WithMetrics(ds, "throughput"), "run"), nil
+}
+
+func TestRegisterFormat(t *testing.T) {
+	path, err := ioutil.TempDir("", "aver")
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(path))
+
+	RegisterFormat("runlog", []string{".runlog"}, loadRunLog)
+	assert.Contains(t, Formats(), "runlog")
+	assert.Panics(t, func() { RegisterFormat("runlog", nil, loadCSV) })
+	assert.Panics(t, func() { RegisterFormat("config", nil, loadCSV) })
+	assert.Panics(t, func() { RegisterFormat("other", nil, nil) })
+
+	log := []byte("raw: 58\nceph: 55.9\nraw: 57\nceph: 54.2\n")
+	assert.Nil(t, ioutil.WriteFile("runs.runlog", log, 0644))
+	assert.Nil(t, ioutil.WriteFile("runs.txt", log, 0644))
+
+	for _, file := range []string{"runs.runlog", "runs.txt"} {
+		ds, err := LoadDataset(file, LoadOptions{})
+		assert.Nil(t, err)
+		r, err := EvaluateDataset(
+			"expect throughput(method='ceph') > throughput(method='raw') * 0.9", ds, Options{})
+		assert.Nil(t, err)
+		assert.True(t, r.Holds)
+	}
+
+	db, tblName, err := MakeDb("runs.txt", "runlog")
+	assert.Nil(t, err)
+	defer db.Close()
+	var cnt int
+	err = db.QueryRow("SELECT count(*) FROM " + tblName + " WHERE throughput > 55").Scan(&cnt)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, cnt)
+
+	_, _, err = MakeDb("runs.txt", "unknown")
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: Unknown file type unknown", err.Error())
+}
+
+func TestReadDataset(t *testing.T) {
+	schema := func(input string, opts LoadOptions) []string {
+		ds, err := ReadDataset(strings.NewReader(input), opts)
+		assert.Nil(t, err)
+		if err != nil {
+			return nil
+		}
+		columns, err := ds.Schema()
+		assert.Nil(t, err)
+		return columns
+	}
+
+	// detected from the content
+	assert.Equal(t, []string{"size", "method", "throughput"},
+		schema("size,method,throughput\n1,raw,58\n", LoadOptions{}))
+	assert.Equal(t, []string{"gomaxprocs", "name"},
+		schema(goBenchOutput, LoadOptions{})[:2])
+	assert.Equal(t, []string{"jobname", "groupid"},
+		schema(fioOutput, LoadOptions{File: "results.json"})[:2])
+	assert.Equal(t, []string{"version", "threads"},
+		schema(sysbenchOutput(4, "999.27", "5.28"), LoadOptions{File: "oltp.log"})[:2])
+	assert.Equal(t, []string{"command", "mean"},
+		schema("command,mean\nsleep 1,1.001\n", LoadOptions{})[:2])
+
+	// given explicitly
+	assert.Equal(t, []string{"command", "mean"},
+		schema("command,mean\nsleep 1,1.001\n", LoadOptions{Format: "csv"}))
+	assert.Equal(t, []string{"size", "method"},
+		schema("size;method\n1;raw\n", LoadOptions{
+			Format: "csv", Settings: map[string]string{"delimiter": ";"}}))
+
+	_, err := ReadDataset(strings.NewReader(goBenchOutput), LoadOptions{Format: "fio"})
+	assert.NotNil(t, err)
+
+	_, err = ReadDataset(strings.NewReader("a,b\n1,2,3\n"), LoadOptions{File: "data.csv"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to detect the format of data.csv: ")
+}
@@ -0,0 +1,160 @@
+package aver
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// NewGoBenchDataset reads the output of 'go test -bench' (see parseGoBench for
+// the columns of the table) into a Dataset held in memory, whose metrics are
+// the number of iterations and the measurements of each benchmark, and whose
+// run column numbers the results of a benchmark run several times (see
+// RunDataset)
+func NewGoBenchDataset(r io.Reader) (Dataset, error) {
+	columns, rows, metrics, err := parseGoBench(r)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := NewMemoryDataset(columns, rows)
+	if err != nil {
+		return nil, err
+	}
+	return WithRuns(WithMetrics(ds, append([]string{"iterations"}, metrics...)...), "run"), nil
+}
+
+// parses the output of 'go test -bench' into a table with one row per
+// benchmark result. Columns are:
+//
+//   - name: the name of the benchmark, without the 'Benchmark' prefix
+//   - one for each '/key=value' segment of the name of a sub-benchmark, named
+//     after the key; segments without '=' go into sub1, sub2, etc. by position
+//   - gomaxprocs: the '-N' suffix of the name (if any)
+//   - one for each configuration line preceding the result, e.g. goos, goarch,
+//     pkg or cpu
+//   - run: which of the results of the benchmark in the same configuration it
+//     is, starting from 1, as there are several of them when it's run more
+//     than once (-count)
+//   - iterations: the number of iterations the result was measured over
+//   - one for each metric, named after its unit: ns_per_op, bytes_per_op
+//     (B/op), allocs_per_op, mb_per_s (MB/s) and so on for custom metrics
+//
+// Columns that a result has no value for are NULL.
+func parseGoBench(r io.Reader) (
+	columns []string, rows [][]interface{}, metrics []string, err error) {
+
+	rxConfig := regexp.MustCompile(`^([a-z][^\sA-Z:]*):\s*(.*)$`)
+	rxProcs := regexp.MustCompile(`-([0-9]+)$`)
+
+	config := make(map[string]string)
+	configKeys := make([]string, 0)
+	runs := make(map[string]int64)
+	var t tableBuilder
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		// configuration lines are not indented, unlike those logged by tests
+		if m := rxConfig.FindStringSubmatch(scanner.Text()); m != nil {
+			key := columnName(m[1])
+			if _, ok := config[key]; !ok {
+				configKeys = append(configKeys, key)
+			}
+			config[key] = m[2]
+			continue
+		}
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		iterations, perr := strconv.ParseInt(safeField(fields, 1), 10, 64)
+		if perr != nil || len(fields)%2 != 0 {
+			// e.g. the name of a benchmark printed by -v before it runs
+			continue
+		}
+
+		values := make(map[int]interface{})
+		name := fields[0]
+		if m := rxProcs.FindStringSubmatch(name); m != nil {
+			name = strings.TrimSuffix(name, m[0])
+			values[t.column("gomaxprocs")] = csvValue(m[1])
+		}
+		segments := strings.Split(name, "/")
+		values[t.column("name")] = strings.TrimPrefix(segments[0], "Benchmark")
+		position := 0
+		for _, segment := range segments[1:] {
+			if kv := strings.SplitN(segment, "=", 2); len(kv) == 2 {
+				values[t.column(columnName(kv[0]))] = csvValue(kv[1])
+				continue
+			}
+			position++
+			values[t.column("sub"+strconv.Itoa(position))] = csvValue(segment)
+		}
+		run := fields[0]
+		for _, key := range configKeys {
+			values[t.column(key)] = csvValue(config[key])
+			run += "\n" + key + ": " + config[key]
+		}
+		runs[run]++
+		values[t.column("run")] = runs[run]
+		values[t.column("iterations")] = iterations
+
+		for i := 2; i < len(fields); i += 2 {
+			value, perr := strconv.ParseFloat(fields[i], 64)
+			if perr != nil {
+				return nil, nil, nil, AverError{"line " + strconv.Itoa(line) +
+					": non-numeric value '" + fields[i] + "' for metric " + fields[i+1]}
+			}
+			metric := metricName(fields[i+1])
+			if !t.has(metric) {
+				metrics = append(metrics, metric)
+			}
+			values[t.column(metric)] = value
+		}
+
+		t.add(values)
+	}
+	if err = scanner.Err(); err != nil {
+		return
+	}
+	if len(t.rows) == 0 {
+		return nil, nil, nil, AverError{"no benchmark results found"}
+	}
+	columns, rows = t.table()
+	return
+}
+
+func safeField(fields []string, i int) string {
+	if i < len(fields) {
+		return fields[i]
+	}
+	return ""
+}
+
+// the name of the column for a metric, e.g. 'ns_per_op' for 'ns/op'
+func metricName(unit string) string {
+	if unit == "B/op" {
+		return "bytes_per_op"
+	}
+	return columnName(strings.Replace(unit, "/", "_per_", -1))
+}
+
+// turns a string into a name that can be used in a statement, replacing
+// runs of anything other than letters, digits and underscores by an underscore
+func columnName(s string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r - 'A' + 'a'
+		}
+		return '_'
+	}, s)
+	for strings.Contains(name, "__") {
+		name = strings.Replace(name, "__", "_", -1)
+	}
+	return strings.Trim(name, "_")
+}
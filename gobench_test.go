@@ -0,0 +1,154 @@
+package aver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const goBenchOutput = `goos: linux
+goarch: amd64
+pkg: github.com/example/codec
+cpu: Intel(R) Xeon(R) CPU @ 2.20GHz
+BenchmarkEncode/size=1024/codec=json-8         	  120000	      9850 ns/op	  103.96 MB/s	    2048 B/op	      12 allocs/op
+BenchmarkEncode/size=1024/codec=gob-8          	  300000	      4120 ns/op	  248.54 MB/s	    1024 B/op	       5 allocs/op
+BenchmarkEncode/size=1024/codec=json-8         	  120000	      9910 ns/op	  103.33 MB/s	    2048 B/op	      12 allocs/op
+BenchmarkEncode/size=1024/codec=gob-8          	  300000	      4080 ns/op	  250.98 MB/s	    1024 B/op	       5 allocs/op
+BenchmarkDecode/small-8
+    codec_test.go:42: decoding 1 message
+BenchmarkDecode/small-8                        	 1000000	      1020 ns/op
+PASS
+ok  	github.com/example/codec	8.214s
+`
+
+func TestParseGoBench(t *testing.T) {
+	columns, rows, metrics, err := parseGoBench(strings.NewReader(goBenchOutput))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"gomaxprocs", "name", "size", "codec", "goos", "goarch", "pkg", "cpu", "run",
+		"iterations", "ns_per_op", "mb_per_s", "bytes_per_op", "allocs_per_op", "sub1",
+	}, columns)
+	assert.Equal(t, []string{"ns_per_op", "mb_per_s", "bytes_per_op", "allocs_per_op"}, metrics)
+	assert.Equal(t, 5, len(rows))
+	assert.Equal(t, []interface{}{
+		int64(8), "Encode", int64(1024), "json", "linux", "amd64",
+		"github.com/example/codec", "Intel(R) Xeon(R) CPU @ 2.20GHz", int64(1),
+		int64(120000), 9850.0, 103.96, 2048.0, 12.0, nil,
+	}, rows[0])
+	assert.Equal(t, []interface{}{
+		int64(8), "Decode", nil, nil, "linux", "amd64",
+		"github.com/example/codec", "Intel(R) Xeon(R) CPU @ 2.20GHz", int64(1),
+		int64(1000000), 1020.0, nil, nil, nil, "small",
+	}, rows[4])
+	assert.Equal(t, int64(2), rows[2][8])
+	assert.Equal(t, int64(2), rows[3][8])
+
+	_, _, _, err = parseGoBench(strings.NewReader("PASS\nok  \tpkg\t0.1s\n"))
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no benchmark results found", err.Error())
+
+	_, _, _, err = parseGoBench(strings.NewReader("BenchmarkA-8 10 fast ns/op\n"))
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: line 1: non-numeric value 'fast' for metric ns/op", err.Error())
+}
+
+func TestGoBenchDataset(t *testing.T) {
+	ds, err := NewGoBenchDataset(strings.NewReader(goBenchOutput))
+	assert.Nil(t, err)
+
+	// metrics don't identify points, so each codec is compared on its own
+	r, err := EvaluateDataset(
+		"expect ns_per_op(name='Encode' and codec='gob') < ns_per_op(name='Encode' and codec='json')",
+		ds, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+
+	r, err = EvaluateDataset(
+		"for name='Encode' expect bytes_per_op(codec='json') < bytes_per_op(codec='gob')",
+		ds, Options{})
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+
+	// repetitions given by -count
+	r, err = EvaluateDataset(
+		"for each codec expect cv(ns_per_op(name='Encode')) < 0.05", ds, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+}
+
+// the output of 'go test -bench . -count 3', where the second run of gob was
+// slower than that of json
+const goBenchCountOutput = `goos: linux
+goarch: amd64
+BenchmarkMarshal/codec=json-4   	   50000	     31200 ns/op
+BenchmarkMarshal/codec=json-4   	   50000	     30900 ns/op
+BenchmarkMarshal/codec=json-4   	   50000	     31500 ns/op
+BenchmarkMarshal/codec=gob-4    	  100000	     15800 ns/op
+BenchmarkMarshal/codec=gob-4    	  100000	     32400 ns/op
+BenchmarkMarshal/codec=gob-4    	  100000	     16100 ns/op
+PASS
+`
+
+func TestGoBenchRuns(t *testing.T) {
+	ds, err := NewGoBenchDataset(strings.NewReader(goBenchCountOutput))
+	assert.Nil(t, err)
+
+	// runs are paired up by their number
+	r, err := EvaluateDataset(
+		"expect ns_per_op(codec='gob') < ns_per_op(codec='json')", ds, Options{})
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, 1, len(r.Counterexamples))
+	assert.Contains(t, r.Counterexamples[0].Point, "run=2")
+
+	r, err = EvaluateDataset(
+		"expect ns_per_op(codec='gob') < ns_per_op(codec='json') "+
+			"excluding outliers by mad 3.5", ds, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+
+	r, err = EvaluateDataset("for each codec expect cv(ns_per_op) < 0.05", ds, Options{})
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Contains(t, r.Worst.Group, "codec='gob'")
+	assert.Equal(t, 3, r.Worst.Count)
+
+	dir, err := ioutil.TempDir("", "aver")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "results.bench")
+	assert.Nil(t, ioutil.WriteFile(file, []byte(goBenchCountOutput), 0644))
+	db, tbl, err := MakeDb(file, "gobench")
+	assert.Nil(t, err)
+	defer db.Close()
+
+	var count int
+	assert.Nil(t, db.QueryRow("SELECT count(*) FROM "+tbl+" WHERE run = 2").Scan(&count))
+	assert.Equal(t, 2, count)
+}
+
+func TestMakeDbFromGoBench(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aver")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "results.bench")
+	assert.Nil(t, ioutil.WriteFile(file, []byte(goBenchOutput), 0644))
+
+	db, tbl, err := MakeDb(file, "gobench")
+	assert.Nil(t, err)
+	defer db.Close()
+
+	var count int
+	assert.Nil(t, db.QueryRow(
+		"SELECT count(*) FROM "+tbl+" WHERE name = 'Encode' AND codec = 'gob'").Scan(&count))
+	assert.Equal(t, 2, count)
+
+	var max float64
+	assert.Nil(t, db.QueryRow(
+		"SELECT max(ns_per_op) FROM "+tbl+" WHERE size > 512 AND codec = 'gob'").Scan(&max))
+	assert.Equal(t, 4120.0, max)
+}
@@ -0,0 +1,153 @@
+package aver
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strings"
+
+	sj "github.com/bitly/go-simplejson"
+)
+
+// the summary statistics that hyperfine reports for each command, in seconds
+var hyperfineSummaries = []string{"mean", "stddev", "median", "user", "system", "min", "max"}
+
+// NewHyperfineDataset reads the results exported by hyperfine, either as JSON
+// (--export-json) or as CSV (--export-csv), into a Dataset held in memory.
+// Columns are:
+//
+//   - command: the benchmarked command, which is told as a metric (see
+//     MetricDataset) so that commands are compared by their parameters
+//   - one for each parameter given with --parameter-scan or
+//     --parameter-list, named after the parameter
+//   - mean, stddev, median, user, system, min and max: the summary statistics
+//     of the command, in seconds
+//
+// The JSON export has the time of each run of a command, so it is read into
+// one row per run, which has two more columns: run, which numbers the runs of
+// a command starting from 1, and time, the wall clock time of the run in
+// seconds (along with exit_code if hyperfine reports it). Runs of a command
+// are repetitions, so run is told as a run column (see RunDataset). The CSV
+// export is read into one row per command.
+func NewHyperfineDataset(r io.Reader) (Dataset, error) {
+	br := bufio.NewReader(r)
+	if first, err := firstNonSpace(br); err != nil {
+		return nil, err
+	} else if first == '{' {
+		return readHyperfineJSON(br)
+	}
+	return readHyperfineCSV(br)
+}
+
+// peeks at the first character of a reader that isn't white space
+func firstNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+			return b, br.UnreadByte()
+		}
+	}
+}
+
+func readHyperfineJSON(r io.Reader) (Dataset, error) {
+	js, err := sj.NewFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	results, err := js.Get("results").Array()
+	if err != nil {
+		return nil, AverError{"expecting 'results' array in hyperfine output"}
+	}
+
+	var t tableBuilder
+	metrics := append([]string{"command", "time", "exit_code"}, hyperfineSummaries...)
+	for i := range results {
+		result := js.Get("results").GetIndex(i)
+		values := make(map[int]interface{})
+		values[t.column("command")] = result.Get("command").MustString()
+		parameters := result.Get("parameters").MustMap()
+		for _, name := range sortedNames(parameters) {
+			values[t.column(columnName(name))] = jsonValue(parameters[name])
+		}
+		for _, s := range hyperfineSummaries {
+			values[t.column(s)] = jsonValue(result.Get(s).Interface())
+		}
+
+		times := result.Get("times").MustArray()
+		exitCodes := result.Get("exit_codes").MustArray()
+		if len(times) == 0 {
+			// exported by a version of hyperfine that leaves out the runs
+			t.add(values)
+			continue
+		}
+		for run := range times {
+			row := make(map[int]interface{})
+			for k, v := range values {
+				row[k] = v
+			}
+			row[t.column("run")] = int64(run + 1)
+			row[t.column("time")] = jsonValue(times[run])
+			if run < len(exitCodes) {
+				row[t.column("exit_code")] = jsonValue(exitCodes[run])
+			}
+			t.add(row)
+		}
+	}
+	if len(t.rows) == 0 {
+		return nil, AverError{"no hyperfine runs found"}
+	}
+
+	hasRuns := t.has("run")
+	columns, rows := t.table()
+	ds, err := NewMemoryDataset(columns, rows)
+	if err != nil {
+		return nil, err
+	}
+	if hasRuns {
+		return WithRuns(WithMetrics(ds, metrics...), "run"), nil
+	}
+	return WithMetrics(ds, metrics...), nil
+}
+
+func readHyperfineCSV(r io.Reader) (Dataset, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, AverError{"no hyperfine results found"}
+	}
+	if len(records[0]) < 2 || records[0][0] != "command" || records[0][1] != "mean" {
+		return nil, AverError{"expecting 'command' and 'mean' columns in hyperfine output"}
+	}
+
+	columns := make([]string, len(records[0]))
+	for i, name := range records[0] {
+		columns[i] = columnName(strings.TrimPrefix(name, "parameter_"))
+	}
+	rows := make([][]interface{}, len(records)-1)
+	for i, record := range records[1:] {
+		rows[i] = make([]interface{}, len(record))
+		for j, cell := range record {
+			rows[i][j] = csvValue(cell)
+		}
+	}
+	ds, err := NewMemoryDataset(columns, rows)
+	if err != nil {
+		return nil, err
+	}
+	return WithMetrics(ds, append([]string{"command"}, hyperfineSummaries...)...), nil
+}
+
+func sortedNames(m map[string]interface{}) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,79 @@
+package aver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHyperfineDataset(t *testing.T) {
+	ds, err := NewHyperfineDataset(strings.NewReader(`
+{
+  "results": [
+    {
+      "command": "gzip -1 data",
+      "mean": 0.105, "stddev": 0.005, "median": 0.104,
+      "user": 0.09, "system": 0.01, "min": 0.1, "max": 0.112,
+      "times": [0.1, 0.104, 0.112],
+      "exit_codes": [0, 0, 0],
+      "parameters": {"level": "1"}
+    },
+    {
+      "command": "gzip -9 data",
+      "mean": 0.305, "stddev": 0.012, "median": 0.301,
+      "user": 0.29, "system": 0.01, "min": 0.295, "max": 0.319,
+      "times": [0.295, 0.301, 0.319],
+      "exit_codes": [0, 0, 0],
+      "parameters": {"level": "9"}
+    }
+  ]
+}`))
+	assert.Nil(t, err)
+
+	columns, err := ds.Schema()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"command", "level", "mean", "stddev", "median", "user", "system", "min", "max",
+		"run", "time", "exit_code",
+	}, columns)
+
+	rows := make([][]interface{}, 0)
+	err = ds.Scan([]string{"level", "run", "time"}, func(values []interface{}) error {
+		rows = append(rows, values)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{
+		{int64(1), int64(1), 0.1}, {int64(1), int64(2), 0.104}, {int64(1), int64(3), 0.112},
+		{int64(9), int64(1), 0.295}, {int64(9), int64(2), 0.301}, {int64(9), int64(3), 0.319},
+	}, rows)
+
+	r, err := EvaluateDataset("expect time(level=9) > time(level=1) * 2", ds, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+
+	r, err = EvaluateDataset("for each level expect cv(time) < 0.1", ds, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+
+	ds, err = NewHyperfineDataset(strings.NewReader(
+		"command,mean,stddev,median,user,system,min,max,parameter_level\n" +
+			"gzip -1 data,0.105,0.005,0.104,0.09,0.01,0.1,0.112,1\n" +
+			"gzip -9 data,0.305,0.012,0.301,0.29,0.01,0.295,0.319,9\n"))
+	assert.Nil(t, err)
+
+	columns, err = ds.Schema()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"command", "mean", "stddev", "median", "user", "system", "min", "max", "level",
+	}, columns)
+
+	r, err = EvaluateDataset("expect median(level=9) > median(level=1) * 2", ds, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+
+	_, err = NewHyperfineDataset(strings.NewReader(`{"results": []}`))
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no hyperfine runs found", err.Error())
+}
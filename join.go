@@ -0,0 +1,114 @@
+package aver
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+// removes the column given in a 'matching' or 'interpolate on' clause from the
+// columns that both sides are joined on
+func (v Validation) withoutMatchColumn(columns []string) (exact []string, err error) {
+	found := false
+	for _, c := range columns {
+		if c == v.matchColumn {
+			found = true
+			continue
+		}
+		exact = append(exact, c)
+	}
+	if !found {
+		return nil, AverError{
+			"'" + v.matchColumn + "' is not a column that both sides are joined on"}
+	}
+	return
+}
+
+// pairs up a left-side point (whose second value is the one of the match
+// column) with the right-side points of the same configuration. For
+// 'matching <column> within <tolerance>', the value is paired with the
+// right-side values whose match column is the closest to the left one, as long
+// as it's within the tolerance (an absolute value, or a percentage of the
+// largest of both values). For 'interpolate on <column>', the right-side
+// values are linearly interpolated at the left-side value of the column;
+// repeated measurements at the same value of the column are averaged.
+func (v Validation) match(l point, right []point) (pairs []pairedPoint, err error) {
+	x := l.values[1]
+	at := predicate{column: v.matchColumn, op: "=",
+		literal: strconv.FormatFloat(x, 'f', -1, 64)}
+	terms := append(append([]predicate{}, l.terms...), at)
+	where := and(conjunction(l.terms), at.String())
+
+	if v.interpolate {
+		y, err := v.interpolateAt(x, right)
+		if err != nil {
+			return nil, prefixError(err, where)
+		}
+		return []pairedPoint{{terms, l.values[0], y}}, nil
+	}
+
+	tolerance, err := strconv.ParseFloat(v.tolerance, 64)
+	if err != nil || tolerance < 0 {
+		return nil, AverError{"Expecting non-negative tolerance; got " + v.tolerance}
+	}
+	closest := math.Inf(1)
+	for _, r := range right {
+		closest = math.Min(closest, math.Abs(r.values[1]-x))
+	}
+	for _, r := range right {
+		distance := math.Abs(r.values[1] - x)
+		if distance != closest {
+			continue
+		}
+		limit := tolerance
+		if v.relativeTolerance {
+			limit = tolerance / 100 * math.Max(math.Abs(x), math.Abs(r.values[1]))
+		}
+		if distance <= limit {
+			pairs = append(pairs, pairedPoint{terms, l.values[0], r.values[0]})
+		}
+	}
+	if len(pairs) == 0 {
+		suffix := ""
+		if v.relativeTolerance {
+			suffix = "%"
+		}
+		return nil, AverError{"no right-side value of '" + v.matchColumn + "' within " +
+			v.tolerance + suffix + " of " + where}
+	}
+	return
+}
+
+// linearly interpolates the curve given by the right-side points at x. Values
+// outside of the range of the curve are not extrapolated.
+func (v Validation) interpolateAt(x float64, right []point) (float64, error) {
+	sums := make(map[float64]float64)
+	counts := make(map[float64]int)
+	xs := make([]float64, 0, len(right))
+	for _, r := range right {
+		if counts[r.values[1]] == 0 {
+			xs = append(xs, r.values[1])
+		}
+		sums[r.values[1]] += r.values[0]
+		counts[r.values[1]]++
+	}
+	if len(xs) == 0 {
+		return 0, AverError{"cannot interpolate '" + v.right.funcName + "' since there " +
+			"are no right-side values of '" + v.matchColumn + "'"}
+	}
+	sort.Float64s(xs)
+	y := func(i int) float64 { return sums[xs[i]] / float64(counts[xs[i]]) }
+
+	i := sort.SearchFloat64s(xs, x)
+	switch {
+	case i < len(xs) && xs[i] == x:
+		return y(i), nil
+	case i == 0 || i == len(xs):
+		return 0, AverError{"cannot interpolate '" + v.right.funcName + "' since " +
+			"it's outside of the range of right-side values of '" + v.matchColumn + "' [" +
+			strconv.FormatFloat(xs[0], 'f', -1, 64) + ", " +
+			strconv.FormatFloat(xs[len(xs)-1], 'f', -1, 64) + "]"}
+	}
+	x0, x1 := xs[i-1], xs[i]
+	return y(i-1) + (x-x0)/(x1-x0)*(y(i)-y(i-1)), nil
+}
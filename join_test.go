@@ -0,0 +1,167 @@
+package aver
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadMeasuredLoadTable(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(`
+		CREATE TABLE measured (
+			workload VARCHAR(255),
+			method VARCHAR(255),
+			load FLOAT,
+			throughput FLOAT
+		)
+	`)
+	assert.Nil(t, err)
+
+	for _, row := range []string{
+		"'read', 'a', 1003.2, 900", "'read', 'a', 1998.5, 1700", "'read', 'a', 3010, 2300",
+		"'read', 'b', 998.7, 800", "'read', 'b', 2003.1, 1500", "'read', 'b', 2995, 2000",
+	} {
+		_, err = db.Exec("INSERT INTO measured VALUES(" + row + ")")
+		assert.Nil(t, err)
+	}
+}
+
+func TestMatchingWithin(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadMeasuredLoadTable(t, db)
+
+	_, err := Holds(
+		"expect throughput(method='a') > throughput(method='b')", db, "measured")
+
+	assert.NotNil(t, err)
+
+	holds, err := Holds(`
+	expect
+	  throughput(method='a') > throughput(method='b')
+	matching load within 1%
+	`, db, "measured")
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+
+	holds, err = Holds(`
+	expect
+	  throughput(method='a') > throughput(method='b') * 1.15
+	matching load within $tolerance
+	`, db, "measured", Params{"tolerance": 20})
+
+	assert.Nil(t, err)
+	assert.False(t, holds)
+
+	r, err := Evaluate(`
+	expect
+	  throughput(method='a') / throughput(method='b') between 1.1 and 1.2
+	matching load within 1%
+	`, db, "measured")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 3, r.Ranges[0].Count)
+
+	_, err = Holds(`
+	expect
+	  throughput(method='a') > throughput(method='b')
+	matching load within 0.1%
+	`, db, "measured")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no right-side value of 'load' within 0.1% of "+
+		"workload='read' and load=1003.2", err.Error())
+}
+
+func TestInterpolateOn(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	_, err := db.Exec(`
+		CREATE TABLE curves (method VARCHAR(255), load FLOAT, throughput FLOAT)
+	`)
+	assert.Nil(t, err)
+	_, err = db.Exec(`
+		INSERT INTO curves VALUES
+		  ('a', 1500, 1200), ('a', 2500, 1800),
+		  ('b', 1000, 800), ('b', 2000, 1500), ('b', 3000, 2000)
+	`)
+	assert.Nil(t, err)
+
+	holds, err := Holds(`
+	expect
+	  throughput(method='a') > throughput(method='b')
+	interpolate on load
+	`, db, "curves")
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+
+	r, err := Evaluate(`
+	expect
+	  throughput(method='a') > throughput(method='b') by 1.05x
+	interpolate on load
+	`, db, "curves")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, "load=1500", r.Points[0].Point)
+	assert.InDelta(t, 1200.0/1150, r.Points[0].Value, 1e-9)
+
+	_, err = db.Exec("INSERT INTO curves VALUES('a', 3500, 2100)")
+	assert.Nil(t, err)
+
+	_, err = Holds(`
+	expect
+	  throughput(method='a') > throughput(method='b')
+	interpolate on load
+	`, db, "curves")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: load=3500: cannot interpolate 'throughput' since it's outside "+
+		"of the range of right-side values of 'load' [1000, 3000]", err.Error())
+
+	_, err = Holds(`
+	expect
+	  throughput(method='a') > throughput(method='b')
+	interpolate on throughput
+	`, db, "curves")
+
+	assert.NotNil(t, err)
+	assert.Equal(t,
+		"aver: 'throughput' is not a column that both sides are joined on", err.Error())
+}
+
+func TestInterpolateOnMissingRightSide(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	_, err := db.Exec(`
+		CREATE TABLE curves (
+			workload VARCHAR(255), method VARCHAR(255), load FLOAT, throughput FLOAT)
+	`)
+	assert.Nil(t, err)
+	_, err = db.Exec(`
+		INSERT INTO curves VALUES
+		  ('read', 'a', 1500, 1200), ('read', 'b', 1000, 800), ('read', 'b', 2000, 1500),
+		  ('write', 'a', 1500, 900), ('write', 'b', 1000, NULL), ('write', 'b', 2000, NULL)
+	`)
+	assert.Nil(t, err)
+
+	_, err = Holds(`
+	for each workload
+	expect
+	  throughput(method='a') > throughput(method='b')
+	interpolate on load
+	on missing skip
+	`, db, "curves")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: for each workload='write': workload='write' and load=1500: "+
+		"cannot interpolate 'throughput' since there are no right-side values of 'load'",
+		err.Error())
+}
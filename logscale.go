@@ -0,0 +1,73 @@
+package aver
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// evaluates a log-scale comparison, i.e. one of the form
+// '<var>(...) <op> <var>(...) by 10x' or
+// '<var>(...) within 1 order of magnitude of <var>(...)'. Values are paired up
+// in the same way as for a regular comparison and the comparison is done on
+// the (base 10) logarithm of the ratio of each pair, which is reported as the
+// observed factor of each point.
+func (v Validation) logScale(ds Dataset) (
+	holds bool, results []PointResult, outliers []Outlier, err error) {
+
+	if v.left.funcName != v.right.funcName {
+		return false, nil, nil, AverError{
+			"Validation comparison; " + v.left.funcName + " distinct to " + v.right.funcName}
+	}
+
+	// the bound on the log-ratio, and the comparison against it
+	var bound float64
+	var op string
+	if v.orders != "" {
+		orders, perr := strconv.ParseFloat(v.orders, 64)
+		if perr != nil || orders < 0 {
+			return false, nil, nil, AverError{
+				"Expecting non-negative number of orders of magnitude; got " + v.orders}
+		}
+		bound, op = orders, "<="
+	} else {
+		factor, perr := strconv.ParseFloat(v.factor, 64)
+		if perr != nil || factor <= 0 {
+			return false, nil, nil, AverError{"Expecting positive factor; got " + v.factor}
+		}
+		op = strings.TrimSpace(v.op)
+		switch op {
+		case ">", ">=":
+			bound = math.Log10(factor)
+		case "<", "<=":
+			bound = -math.Log10(factor)
+		default:
+			return false, nil, nil, AverError{
+				"'by " + v.factor + "x' can only be used with <, <=, > or >="}
+		}
+	}
+
+	pairs, outliers, err := v.pairPoints(ds)
+	if err != nil {
+		return
+	}
+
+	holds = true
+	for _, p := range pairs {
+		point := conjunction(p.terms)
+		if p.left <= 0 || p.right <= 0 {
+			return false, nil, nil, AverError{"log-scale comparisons require positive values " +
+				"but '" + v.left.funcName + "' is not positive for point " + point}
+		}
+		r := PointResult{Point: point, Value: p.left / p.right, Bound: math.Pow(10, bound)}
+		logRatio := math.Log10(r.Value)
+		if v.orders != "" {
+			r.Holds = compare(math.Abs(logRatio), op, bound)
+		} else {
+			r.Holds = compare(logRatio, op, bound)
+		}
+		holds = holds && r.Holds
+		results = append(results, r)
+	}
+	return
+}
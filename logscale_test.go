@@ -0,0 +1,89 @@
+package aver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByFactor(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadWorkloadTable(t, db)
+
+	r, err := Evaluate(
+		"expect throughput(method='a') > throughput(method='b') by 1.5x", db, "workloads")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, 4, len(r.Points))
+	assert.Equal(t, "size=1 and workload='read'", r.Points[0].Point)
+	assert.InDelta(t, 141.0/70, r.Points[0].Value, 1e-9)
+	assert.InDelta(t, 1.5, r.Points[0].Bound, 1e-9)
+	assert.True(t, r.Points[0].Holds)
+	assert.Equal(t, "size=2 and workload='write'", r.Points[3].Point)
+	assert.False(t, r.Points[3].Holds)
+
+	holds, err := Holds(`
+	for workload='read'
+	expect
+	  throughput(method='b') < throughput(method='a') by $factor x
+	`, db, "workloads", Params{"factor": 2})
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+
+	holds, err = Holds(
+		"expect throughput(method='a') > throughput(method='b') by 10x", db, "workloads")
+
+	assert.Nil(t, err)
+	assert.False(t, holds)
+}
+
+func TestOrdersOfMagnitude(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadWorkloadTable(t, db)
+
+	r, err := Evaluate(`
+	expect
+	  throughput(method='b') within 1 order of magnitude of throughput(method='a')
+	`, db, "workloads")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.InDelta(t, 10.0, r.Points[0].Bound, 1e-9)
+
+	holds, err := Holds(`
+	expect
+	  throughput(method='b') within 0.1 orders of magnitude of throughput(method='a')
+	`, db, "workloads")
+
+	assert.Nil(t, err)
+	assert.False(t, holds)
+}
+
+func TestLogScaleErrors(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadWorkloadTable(t, db)
+
+	_, err := Holds(
+		"expect throughput(method='a') = throughput(method='b') by 10x", db, "workloads")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: 'by 10x' can only be used with <, <=, > or >=", err.Error())
+
+	_, err = db.Exec("UPDATE workloads SET throughput = 0 WHERE size = 2 AND method = 'b'")
+	assert.Nil(t, err)
+
+	_, err = Holds(
+		"expect throughput(method='a') > throughput(method='b') by 10x", db, "workloads")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: log-scale comparisons require positive values but "+
+		"'throughput' is not positive for point size=2 and workload='read'", err.Error())
+}
@@ -0,0 +1,142 @@
+package aver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MissingPolicy tells how rows with missing values (NULL, or empty as it
+// happens for empty cells in CSV files) in the columns a statement refers to
+// are treated. It can be given to EvaluateWithOptions or in the statement
+// itself ('on missing skip'), which takes precedence.
+type MissingPolicy string
+
+const (
+	// MissingFail makes a statement (or the 'for each' group) fail if any of
+	// the rows it refers to has missing values. This is the default, and
+	// corresponds to how Holds has always treated them.
+	MissingFail MissingPolicy = "fail"
+	// MissingSkip ignores rows with missing values, along with the values they
+	// are paired with on the other side of a comparison
+	MissingSkip MissingPolicy = "skip"
+	// MissingError makes the evaluation fail with an error
+	MissingError MissingPolicy = "error"
+)
+
+func (m MissingPolicy) valid() bool {
+	return m == MissingFail || m == MissingSkip || m == MissingError
+}
+
+// NumericColumns returns the numeric columns a statement refers to, i.e. the
+// dependent variable(s), the columns given to functions such as 'corr' or
+// 'speedup' and the one that both sides are joined on approximately
+func (v Validation) NumericColumns() (columns []string) {
+	names := []string{v.left.funcName}
+	if v.right.funcName != v.left.funcName {
+		names = append(names, v.right.funcName)
+	}
+	names = append(append(names,
+		v.covariate, v.scaleColumn, v.thresholdColumn, v.matchColumn), v.objectives...)
+	for _, name := range names {
+		if _, err := strconv.ParseFloat(name, 64); name == "" || err == nil {
+			continue
+		}
+		columns = append(columns, name)
+	}
+	return
+}
+
+// predicates of the sides of a statement that select rows (the right side of
+// a comparison against a numeric literal doesn't). An empty predicate stands
+// for every row.
+func (v Validation) sides() [][]predicate {
+	if _, err := strconv.ParseFloat(v.right.funcName, 64); err == nil {
+		return [][]predicate{v.left.terms}
+	}
+	return [][]predicate{v.left.terms, v.right.terms}
+}
+
+// counts the rows satisfying the global predicates and any of the sides of the
+// given comparisons that have missing values in the columns they refer to
+func countMissing(ds Dataset, global []predicate, comparisons []Validation) (
+	count int, err error) {
+
+	if len(comparisons) == 0 {
+		return 0, nil
+	}
+	columns := comparisons[0].NumericColumns()
+	if len(columns) == 0 {
+		return 0, nil
+	}
+
+	// a row is counted once even if it's on several sides, so the predicates
+	// of the sides are matched against the rows selected by the global ones
+	sides := sidesOf(comparisons)
+	keys := make([]string, 0)
+	for _, side := range sides {
+		for _, p := range side {
+			keys = append(keys, p.Column)
+		}
+	}
+
+	points, err := selectPoints(ds, keys, columns, global)
+	if err != nil {
+		return
+	}
+	for _, p := range points {
+		if p.missing && onAnySide(p.terms, sides) {
+			count++
+		}
+	}
+	return
+}
+
+// conjunctions of the sides of the given comparisons, or nil if any of them
+// takes every row
+func sidesOf(comparisons []Validation) [][]Predicate {
+	sides := make([][]Predicate, 0)
+	for _, c := range comparisons {
+		for _, side := range c.sides() {
+			if len(side) == 0 {
+				return nil
+			}
+			predicates := make([]Predicate, len(side))
+			for i, p := range side {
+				predicates[i] = p.export()
+			}
+			sides = append(sides, predicates)
+		}
+	}
+	return sides
+}
+
+// whether a row, given by its (equality) terms, satisfies any of the given
+// conjunctions, which it does if there are none
+func onAnySide(terms []predicate, sides [][]Predicate) bool {
+	if len(sides) == 0 {
+		return true
+	}
+	values := make(map[string]interface{})
+	for _, t := range terms {
+		values[strings.ToLower(t.column)] = t.export().Value
+	}
+sideLoop:
+	for _, side := range sides {
+		for _, p := range side {
+			if !p.matches(values[strings.ToLower(p.Column)]) {
+				continue sideLoop
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// error returned when the policy is MissingError
+func missingError(count int) error {
+	if count == 1 {
+		return AverError{"1 row with missing values"}
+	}
+	return AverError{fmt.Sprintf("%d rows with missing values", count)}
+}
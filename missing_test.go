@@ -0,0 +1,138 @@
+package aver
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadIncompleteTable(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(`
+		CREATE TABLE incomplete (
+			size INT,
+			method VARCHAR(255),
+			throughput FLOAT
+		)
+	`)
+	assert.Nil(t, err)
+
+	for _, row := range []string{
+		"1, 'a', 100", "2, 'a', 110", "3, 'a', NULL",
+		"1, 'b', 50", "2, 'b', ''", "3, 'b', 60",
+		"1, 'c', 70", "2, 'c', 80", "3, 'c', 90",
+	} {
+		_, err = db.Exec("INSERT INTO incomplete VALUES(" + row + ")")
+		assert.Nil(t, err)
+	}
+}
+
+func TestMissingValues(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadIncompleteTable(t, db)
+
+	// missing values make the statement fail by default
+	r, err := Evaluate(
+		"expect throughput(method='a') > throughput(method='b')", db, "incomplete")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, 2, r.Missing)
+
+	r, err = Evaluate(`
+	expect
+	  throughput(method='a') > throughput(method='b')
+	on missing skip
+	`, db, "incomplete")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 2, r.Missing)
+
+	r, err = EvaluateWithOptions(
+		"expect throughput(method='a') > throughput(method='b') * 3", db, "incomplete",
+		Options{Missing: MissingSkip})
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+
+	// rows that the statement doesn't refer to are not taken into account
+	r, err = Evaluate(
+		"expect throughput(method='c') > throughput(method='b') on missing skip", db, "incomplete")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, r.Missing)
+
+	r, err = Evaluate("for size=1 and method='a' expect throughput > 90", db, "incomplete")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 0, r.Missing)
+}
+
+func TestMissingValuesError(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadIncompleteTable(t, db)
+
+	_, err := EvaluateWithOptions(
+		"expect throughput(method='a') > throughput(method='b')", db, "incomplete",
+		Options{Missing: MissingError})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: 2 rows with missing values", err.Error())
+
+	_, err = Holds(`
+	for each method
+	expect
+	  throughput > 60
+	on missing error
+	`, db, "incomplete")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: for each method='a': 1 row with missing values", err.Error())
+
+	// the statement takes precedence
+	holds, err := EvaluateWithOptions(
+		"expect throughput(method='a') > throughput(method='b') on missing skip",
+		db, "incomplete", Options{Missing: MissingError})
+
+	assert.Nil(t, err)
+	assert.True(t, holds.Holds)
+
+	_, err = EvaluateWithOptions(
+		"expect throughput(method='a') > throughput(method='b')", db, "incomplete",
+		Options{Missing: "ignore"})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: unknown policy for missing values 'ignore'", err.Error())
+}
+
+func TestMissingValuesCorrelation(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadIncompleteTable(t, db)
+
+	r, err := Evaluate(
+		"for each method expect corr(throughput, size) > 0.9 on missing skip", db, "incomplete")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 2, r.Missing)
+	assert.Equal(t, 2, r.Correlations[0].Count)
+
+	r, err = Evaluate(
+		"for each method expect corr(throughput, size) > 0.9", db, "incomplete")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []GroupResult{
+		{"method='a'", false},
+		{"method='b'", false},
+		{"method='c'", true},
+	}, r.Groups)
+}
@@ -0,0 +1,215 @@
+package aver
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Outlier is a row that was left out of the evaluation of a statement by an
+// 'excluding outliers' clause
+type Outlier struct {
+	// Group is the 'for each' group the row belongs to (if any)
+	Group string
+	// Point is the conjunction of predicates that identifies the
+	// configuration of the row, e.g. "method='b' and size=1"
+	Point string
+	// Value of the dependent variable
+	Value float64
+}
+
+// minimum number of values that a configuration group needs to have for
+// outliers to be detected in it
+const minOutlierGroupSize = 3
+
+// returns, for the given values, which ones are outliers according to the
+// given method and threshold:
+//
+//   - iqr: values outside of [Q1 - k*IQR, Q3 + k*IQR]
+//   - zscore: values that are more than k standard deviations away from the
+//     mean
+//   - mad: values whose modified z-score (0.6745 * |x - median| / MAD) is
+//     greater than k, as proposed by Iglewicz and Hoaglin
+func outlierMask(xs []float64, method string, k float64) []bool {
+	mask := make([]bool, len(xs))
+	if len(xs) < minOutlierGroupSize {
+		return mask
+	}
+	switch method {
+	case "iqr":
+		q1, q3 := quantile(xs, 0.25), quantile(xs, 0.75)
+		low, high := q1-k*(q3-q1), q3+k*(q3-q1)
+		for i, x := range xs {
+			mask[i] = x < low || x > high
+		}
+	case "zscore":
+		m, s := mean(xs), stddev(xs)
+		if s == 0 {
+			return mask
+		}
+		for i, x := range xs {
+			mask[i] = math.Abs(x-m)/s > k
+		}
+	case "mad":
+		m, d := median(xs), mad(xs)
+		if d == 0 {
+			return mask
+		}
+		for i, x := range xs {
+			mask[i] = 0.6745*math.Abs(x-m)/d > k
+		}
+	}
+	return mask
+}
+
+// returns a function that tells which of a group of values are outliers, or
+// nil if the statement has no 'excluding outliers' clause
+func (v Validation) outlierDetector() (func([]float64) []bool, error) {
+	if v.outliers == "" {
+		return nil, nil
+	}
+	k, err := strconv.ParseFloat(v.outlierThreshold, 64)
+	if err != nil || k < 0 {
+		return nil, AverError{
+			"Expecting non-negative threshold for outliers; got " + v.outlierThreshold}
+	}
+	return func(xs []float64) []bool { return outlierMask(xs, v.outliers, k) }, nil
+}
+
+// removes the outliers of the dependent variable (the first of the numeric
+// values of a point) within each configuration group, i.e. among the points
+// of a side of a comparison that have the same values on every join column
+func (v Validation) excludeOutliers(points []point, side Value) (
+	kept []point, outliers []Outlier, err error) {
+
+	isOutlier, err := v.outlierDetector()
+	if err != nil || isOutlier == nil {
+		return points, nil, err
+	}
+
+	keys := make([]string, 0)
+	groups := make(map[string][]int)
+	for i, p := range points {
+		key := conjunction(p.terms)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	excluded := make([]bool, len(points))
+	for _, key := range keys {
+		values := make([]float64, len(groups[key]))
+		for i, j := range groups[key] {
+			values[i] = points[j].values[0]
+		}
+		for i, outlier := range isOutlier(values) {
+			excluded[groups[key][i]] = outlier
+		}
+	}
+
+	for i, p := range points {
+		if !excluded[i] {
+			kept = append(kept, p)
+			continue
+		}
+		outliers = append(outliers, Outlier{
+			Point: conjunction(append(append([]predicate{}, side.terms...), p.terms...)),
+			Value: p.values[0]})
+	}
+	return
+}
+
+// evaluates a comparison outside of the database, returning the points for
+// which it doesn't hold as counterexamples (see pairPoints)
+func (v Validation) comparePoints(ds Dataset) (
+	holds bool, counterexamples []PointResult, outliers []Outlier, err error) {
+
+	isRightNumeric, err := v.checkComparison()
+	if err != nil {
+		return
+	}
+	op := strings.TrimSpace(v.op)
+
+	if isRightNumeric {
+		if v.matchColumn != "" {
+			return false, nil, nil, AverError{
+				"'matching' and 'interpolate' require values on both sides of the comparison"}
+		}
+		threshold, _ := strconv.ParseFloat(v.right.funcName, 64)
+		columns, err := v.joinColumns(ds)
+		if err != nil {
+			return false, nil, nil, err
+		}
+		if v.outliers != "" {
+			columns = withoutRuns(ds, columns)
+		}
+		points, err := selectPoints(
+			ds, columns, []string{v.left.funcName}, v.left.terms, v.globalTerms)
+		if err != nil {
+			return false, nil, nil, err
+		}
+		if len(points) == 0 {
+			return false, nil, nil, AverError{"no values associated to left-side predicates"}
+		}
+		if err = v.checkLiteralSide(ds, columns, points); err != nil {
+			return false, nil, nil, err
+		}
+		points, outliers, err = v.excludeOutliers(present(points), v.left)
+		if err != nil {
+			return false, nil, nil, err
+		}
+		for _, p := range points {
+			if !compare(p.values[0], op, threshold) {
+				counterexamples = append(counterexamples,
+					v.counterexample(p.terms, p.values[0], threshold))
+			}
+		}
+		return len(counterexamples) == 0, counterexamples, outliers, nil
+	}
+
+	factor := 1.0
+	if v.relative != "" {
+		if factor, err = strconv.ParseFloat(v.relative, 64); err != nil {
+			return false, nil, nil, AverError{
+				"Expecting numeric relative factor; got " + v.relative}
+		}
+	}
+	pairs, outliers, err := v.pairPoints(ds)
+	if err != nil {
+		return
+	}
+	for _, p := range pairs {
+		if !compare(p.left, op, p.right*factor) {
+			counterexamples = append(counterexamples,
+				v.counterexample(p.terms, p.left, p.right*factor))
+		}
+	}
+	return len(counterexamples) == 0, counterexamples, outliers, nil
+}
+
+// checks the points of the left side of a comparison against a literal as Holds
+// does in SQL, i.e. against the rows selected by the right side, even though
+// the literal is all that is taken from it (see checkSides). Outliers are
+// looked for among several values of each configuration, which are allowed.
+func (v Validation) checkLiteralSide(ds Dataset, columns []string, left []point) error {
+	if v.outliers != "" {
+		return nil
+	}
+	right, err := selectPoints(
+		ds, columns, []string{v.left.funcName}, v.right.terms, v.globalTerms)
+	if err != nil {
+		return err
+	}
+	if len(right) == 0 {
+		return AverError{"no values associated to right-side predicates"}
+	}
+	return checkSides(left, right)
+}
+
+// a point of the left side of a comparison for which it doesn't hold
+func (v Validation) counterexample(terms []predicate, value, bound float64) PointResult {
+	return PointResult{
+		Point: conjunction(append(append([]predicate{}, v.left.terms...), terms...)),
+		Value: value, Bound: bound}
+}
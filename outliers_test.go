@@ -0,0 +1,133 @@
+package aver
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadNoisyRunsTable(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(`
+		CREATE TABLE noisy (
+			size INT,
+			method VARCHAR(255),
+			throughput FLOAT
+		)
+	`)
+	assert.Nil(t, err)
+
+	values := map[string][]int{
+		"1, 'a'": {100, 101, 99, 100, 40},
+		"2, 'a'": {200, 201, 199, 200, 202},
+		"1, 'b'": {50, 51, 49, 50, 52},
+		"2, 'b'": {100, 99, 101, 100, 98},
+	}
+	for _, config := range []string{"1, 'a'", "2, 'a'", "1, 'b'", "2, 'b'"} {
+		for _, value := range values[config] {
+			_, err = db.Exec(fmt.Sprintf("INSERT INTO noisy VALUES(%s, %d)", config, value))
+			assert.Nil(t, err)
+		}
+	}
+}
+
+func TestOutlierMask(t *testing.T) {
+	xs := []float64{100, 101, 99, 100, 40}
+
+	assert.Equal(t, []bool{false, false, false, false, true}, outlierMask(xs, "iqr", 1.5))
+	assert.Equal(t, []bool{false, false, false, false, true}, outlierMask(xs, "zscore", 1.5))
+	assert.Equal(t, []bool{false, false, false, false, false}, outlierMask(xs, "zscore", 3))
+	assert.Equal(t, []bool{false, false, false, false, true}, outlierMask(xs, "mad", 3.5))
+
+	// too few values to tell
+	assert.Equal(t, []bool{false, false}, outlierMask([]float64{1, 100}, "iqr", 1.5))
+}
+
+func TestExcludingOutliers(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadNoisyRunsTable(t, db)
+
+	r, err := Evaluate(`
+	expect
+	  throughput(method='a') > throughput(method='b') * 1.9
+	excluding outliers by iqr 1.5
+	`, db, "noisy")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, []Outlier{{"", "method='a' and size=1", 40}}, r.Outliers)
+
+	holds, err := Holds(`
+	expect
+	  throughput(method='a') > throughput(method='b') * 1.9
+	excluding outliers by zscore $z
+	`, db, "noisy", Params{"z": 3})
+
+	assert.Nil(t, err)
+	assert.False(t, holds)
+
+	holds, err = Holds(`
+	for size=1
+	expect
+	  throughput(method='a') > 98
+	excluding outliers by mad 3.5
+	`, db, "noisy")
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+
+	r, err = Evaluate(`
+	expect
+	  throughput(method='a') / throughput(method='b') between 1.9 and 2.1
+	excluding outliers by iqr 1.5
+	`, db, "noisy")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 1, len(r.Outliers))
+	assert.InDelta(t, 99.0/52, r.Ranges[0].Min, 1e-9)
+}
+
+func TestExcludingOutliersVariability(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadNoisyRunsTable(t, db)
+
+	holds, err := Holds("for each size expect cv(throughput(method='a')) < 0.01", db, "noisy")
+
+	assert.Nil(t, err)
+	assert.False(t, holds)
+
+	r, err := Evaluate(`
+	for each size
+	expect
+	  cv(throughput(method='a')) < 0.01
+	excluding outliers by iqr 1.5
+	`, db, "noisy")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 4, r.Statistics[0].Count)
+	assert.Equal(t, []Outlier{{"size=1", "method='a' and size=1", 40}}, r.Outliers)
+}
+
+func TestExcludingOutliersErrors(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadNoisyRunsTable(t, db)
+
+	_, err := Holds(`
+	expect
+	  corr(throughput, size) > 0.9
+	excluding outliers by iqr 1.5
+	`, db, "noisy")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: excluding outliers is only supported for comparisons, "+
+		"ratios and variability statements", err.Error())
+}
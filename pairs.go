@@ -0,0 +1,115 @@
+package aver
+
+import (
+	"strconv"
+)
+
+// whether a statement compares more than one pair of subsets, i.e. it's an
+// all-pairs ('*other*') or a 'rank' statement
+func (v Validation) isPairwise() bool {
+	if len(v.ranking) > 0 {
+		return true
+	}
+	for _, p := range v.right.terms {
+		if p.other {
+			return true
+		}
+	}
+	return false
+}
+
+// expands all-pairs and 'rank' statements into the pairwise comparisons they
+// stand for. Any other statement expands to itself.
+//
+// A statement such as
+//
+//	expect throughput(method='a') > throughput(method=*other*)
+//
+// is expanded into one comparison for each distinct value of 'method' found
+// in the table (other than 'a'), while
+//
+//	rank throughput by method: a > b > c
+//
+// is expanded into 'throughput(method='a') > throughput(method='b')' and
+// 'throughput(method='b') > throughput(method='c')'.
+func (v Validation) expand(ds Dataset) (comparisons []Validation, err error) {
+	if len(v.ranking) > 0 {
+		for i, op := range v.rankOps {
+			c := v
+			c.ranking, c.rankOps = nil, nil
+			c.left = Value{funcName: v.left.funcName, terms: v.ranking[i : i+1]}
+			c.left.predicates = conjunction(c.left.terms)
+			c.right = Value{funcName: v.right.funcName, terms: v.ranking[i+1 : i+2]}
+			c.right.predicates = conjunction(c.right.terms)
+			c.op = op
+			comparisons = append(comparisons, c)
+		}
+		return
+	}
+
+	for _, p := range v.globalTerms {
+		if p.other {
+			return nil, AverError{"*other* is only supported in right-side predicates"}
+		}
+	}
+	for _, p := range v.left.terms {
+		if p.other {
+			return nil, AverError{"*other* is only supported in right-side predicates"}
+		}
+	}
+
+	other := -1
+	for i, p := range v.right.terms {
+		if !p.other {
+			continue
+		}
+		if other != -1 {
+			return nil, AverError{"only one *other* predicate is supported"}
+		}
+		if p.op != "=" {
+			return nil, AverError{"*other* can only be used in equality predicates"}
+		}
+		other = i
+	}
+	if other == -1 {
+		return []Validation{v}, nil
+	}
+
+	// the value that the column takes on the left side (if any)
+	column := v.right.terms[other].column
+	var left *predicate
+	for i, p := range v.left.terms {
+		if p.column == column && p.op == "=" {
+			left = &v.left.terms[i]
+		}
+	}
+
+	values, err := distinctValues(ds, []string{column}, v.globalTerms)
+	if err != nil {
+		return
+	}
+	for _, value := range values {
+		if left != nil && sameLiteral(*left, value[0]) {
+			continue
+		}
+		c := v
+		c.right.terms = append([]predicate{}, v.right.terms...)
+		c.right.terms[other] = value[0]
+		c.right.predicates = conjunction(c.right.terms)
+		comparisons = append(comparisons, c)
+	}
+	if len(comparisons) == 0 {
+		return nil, AverError{"no values of '" + column + "' to compare against"}
+	}
+	return
+}
+
+// compares the literals of two predicates, numerically if possible
+func sameLiteral(a, b predicate) bool {
+	if a.literal == b.literal {
+		return true
+	}
+	x, errA := strconv.ParseFloat(a.literal, 64)
+	y, errB := strconv.ParseFloat(b.literal, 64)
+	return errA == nil && errB == nil && x == y
+}
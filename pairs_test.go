@@ -0,0 +1,131 @@
+package aver
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadMethodsTable(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(`
+		CREATE TABLE methods (
+			size INT,
+			method VARCHAR(255),
+			throughput FLOAT
+		)
+	`)
+	assert.Nil(t, err)
+
+	for _, row := range []string{
+		"1, 'a', 150", "1, 'b', 120", "1, 'c', 90", "1, 'd', 155",
+		"2, 'a', 250", "2, 'b', 220", "2, 'c', 190", "2, 'd', 200",
+	} {
+		_, err = db.Exec("INSERT INTO methods VALUES(" + row + ")")
+		assert.Nil(t, err)
+	}
+}
+
+func TestAllPairs(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadMethodsTable(t, db)
+
+	r, err := Evaluate(`
+	expect
+	  throughput(method='a') > throughput(method=*other*)
+	`, db, "methods")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []PairResult{
+		{"", "method='a'", "method='b'", true},
+		{"", "method='a'", "method='c'", true},
+		{"", "method='a'", "method='d'", false},
+	}, r.Pairs)
+
+	r, err = Evaluate(`
+	for each size
+	expect
+	  throughput(method='a') > throughput(method=*other*)
+	`, db, "methods")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []GroupResult{{"size=1", false}, {"size=2", true}}, r.Groups)
+	assert.Equal(t, 6, len(r.Pairs))
+	assert.Equal(t, PairResult{"size=1", "method='a'", "method='d'", false}, r.Pairs[2])
+
+	holds, err := Holds(`
+	for size > 1
+	expect
+	  throughput(method='a') > throughput(method=*other*)
+	`, db, "methods")
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+}
+
+func TestAllPairsErrors(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadMethodsTable(t, db)
+
+	_, err := Holds(`
+	expect
+	  throughput(method=*other*) > throughput(method='a')
+	`, db, "methods")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: *other* is only supported in right-side predicates", err.Error())
+
+	_, err = db.Exec("INSERT INTO methods VALUES(1, 'e', 10)")
+	assert.Nil(t, err)
+
+	_, err = Holds(`
+	expect
+	  throughput(method='a') > throughput(method=*other*)
+	`, db, "methods")
+
+	assert.NotNil(t, err)
+	assert.Equal(t,
+		"aver: method='a' vs. method='e': number of values doesn't match for left/right predicates",
+		err.Error())
+}
+
+func TestRank(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadMethodsTable(t, db)
+
+	r, err := Evaluate(`
+	rank throughput by method: a > b > c
+	`, db, "methods")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, []PairResult{
+		{"", "method='a'", "method='b'", true},
+		{"", "method='b'", "method='c'", true},
+	}, r.Pairs)
+
+	r, err = Evaluate(`
+	for size = 2
+	rank throughput by method: 'd' >= a > b
+	`, db, "methods")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []PairResult{
+		{"", "method='d'", "method='a'", false},
+		{"", "method='a'", "method='b'", true},
+	}, r.Pairs)
+
+	holds, err := Holds("rank throughput by size: 2 > 1", db, "methods")
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+}
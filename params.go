@@ -0,0 +1,179 @@
+package aver
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Params holds the values bound to the placeholders of a statement. A
+// placeholder is written as '$name' or ':name' and can appear wherever a
+// literal is expected: in predicates (`method = :baseline`), as the relative
+// factor (`* $factor`), as a numeric right-hand side (`throughput > $min`) or
+// as a bound (`between $low and $high`).
+// Strings that are numbers as written in statements (e.g. '-2' or '0.5') are
+// bound as numeric literals, and any other string as a string literal, which
+// can't contain quotes or backslashes.
+type Params map[string]interface{}
+
+// the numbers of the grammar (see the 'number' rule of parser.peg)
+var rxNumber = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// ParseParam parses a 'name=value' pair, as given to the CLI
+func ParseParam(param string) (name string, value string, err error) {
+	kv := strings.SplitN(param, "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return "", "", AverError{"Expecting 'name=value' for parameter " + param}
+	}
+	return kv[0], kv[1], nil
+}
+
+// MergeParams merges several Params into one, as given to the functions that
+// take them variadically; later values of a parameter take precedence
+func MergeParams(params ...Params) Params {
+	merged := Params{}
+	for _, p := range params {
+		for k, v := range p {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// literal returns the textual representation of a parameter value and
+// whether it's numeric
+func (ps Params) literal(name string) (lit string, numeric bool, err error) {
+	value, ok := ps[name]
+	if !ok {
+		return "", false, AverError{"unbound parameter '" + name + "'"}
+	}
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), true, nil
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return "", false, AverError{"invalid value for parameter '" + name + "'"}
+		}
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), true, nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return "", false, AverError{"invalid value for parameter '" + name + "'"}
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), true, nil
+	case string:
+		// MySQL takes backslashes as escapes within string literals
+		if strings.ContainsAny(v, `'\`) {
+			return "", false, AverError{"invalid value for parameter '" + name + "'"}
+		}
+		return v, rxNumber.MatchString(v), nil
+	default:
+		return "", false, AverError{
+			fmt.Sprintf("unsupported type %T for parameter '%s'", value, name)}
+	}
+}
+
+func isParam(s string) bool {
+	return strings.HasPrefix(s, "$") || strings.HasPrefix(s, ":")
+}
+
+// bind replaces placeholders in a validation with the values given in params.
+// Every placeholder has to be bound and every parameter has to be used.
+func (v Validation) bind(params Params) (Validation, error) {
+	used := make(map[string]bool)
+
+	bindTerms := func(terms []predicate) ([]predicate, error) {
+		bound := make([]predicate, len(terms))
+		for i, p := range terms {
+			bound[i] = p
+			if !p.param {
+				continue
+			}
+			name := p.literal[1:]
+			lit, numeric, err := params.literal(name)
+			if err != nil {
+				return nil, err
+			}
+			used[name] = true
+			bound[i].literal, bound[i].quoted, bound[i].param = lit, !numeric, false
+		}
+		return bound, nil
+	}
+	bindNumber := func(s string) (string, error) {
+		if !isParam(s) {
+			return s, nil
+		}
+		name := s[1:]
+		lit, numeric, err := params.literal(name)
+		if err != nil {
+			return "", err
+		}
+		if !numeric {
+			return "", AverError{"expecting numeric value for parameter '" + name + "'"}
+		}
+		used[name] = true
+		return lit, nil
+	}
+
+	var err error
+	if v.globalTerms, err = bindTerms(v.globalTerms); err != nil {
+		return v, err
+	}
+	if v.left.terms, err = bindTerms(v.left.terms); err != nil {
+		return v, err
+	}
+	if v.right.terms, err = bindTerms(v.right.terms); err != nil {
+		return v, err
+	}
+	if v.left.funcName, err = bindNumber(v.left.funcName); err != nil {
+		return v, err
+	}
+	if v.right.funcName, err = bindNumber(v.right.funcName); err != nil {
+		return v, err
+	}
+	if v.relative, err = bindNumber(v.relative); err != nil {
+		return v, err
+	}
+	if v.significance, err = bindNumber(v.significance); err != nil {
+		return v, err
+	}
+	if v.baseline, err = bindNumber(v.baseline); err != nil {
+		return v, err
+	}
+	if v.lower, err = bindNumber(v.lower); err != nil {
+		return v, err
+	}
+	if v.upper, err = bindNumber(v.upper); err != nil {
+		return v, err
+	}
+	if v.factor, err = bindNumber(v.factor); err != nil {
+		return v, err
+	}
+	if v.orders, err = bindNumber(v.orders); err != nil {
+		return v, err
+	}
+	if v.outlierThreshold, err = bindNumber(v.outlierThreshold); err != nil {
+		return v, err
+	}
+	if v.tolerance, err = bindNumber(v.tolerance); err != nil {
+		return v, err
+	}
+	v.global = conjunction(v.globalTerms)
+	v.left.predicates = conjunction(v.left.terms)
+	v.right.predicates = conjunction(v.right.terms)
+
+	unused := make([]string, 0)
+	for name := range params {
+		if !used[name] {
+			unused = append(unused, name)
+		}
+	}
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		return v, AverError{"unused parameter(s) '" + strings.Join(unused, "', '") + "'"}
+	}
+
+	return v, nil
+}
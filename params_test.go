@@ -0,0 +1,156 @@
+package aver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var parameterized = `
+	for
+		size > $min_size
+	expect
+	  throughput(method=:method) > throughput(method=:baseline) * $factor
+	`
+
+func TestParsePlaceholders(t *testing.T) {
+	v, err := ParseValidation(parameterized)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "size>$min_size", v.global)
+	assert.Equal(t, "method=:method", v.left.predicates)
+	assert.Equal(t, "method=:baseline", v.right.predicates)
+	assert.Equal(t, "$factor", v.relative)
+
+	v, err = ParseValidation("expect throughput > $min")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "$min", v.right.funcName)
+}
+
+func TestBindParams(t *testing.T) {
+	v, err := ParseValidation(parameterized)
+	assert.Nil(t, err)
+
+	v, err = v.bind(Params{
+		"min_size": 3, "method": "ceph", "baseline": "raw", "factor": "0.9"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "size>3", v.global)
+	assert.Equal(t, "method='ceph'", v.left.predicates)
+	assert.Equal(t, "method='raw'", v.right.predicates)
+	assert.Equal(t, "0.9", v.relative)
+}
+
+func TestParamLiterals(t *testing.T) {
+	for _, c := range []struct {
+		value   interface{}
+		literal string
+		numeric bool
+		valid   bool
+	}{
+		{"2", "2", true, true},
+		{"-0.5", "-0.5", true, true},
+		{3, "3", true, true},
+		{0.25, "0.25", true, true},
+		{1e21, "1000000000000000000000", true, true},
+		{"ceph", "ceph", false, true},
+		{"nan", "nan", false, true},
+		{"Inf", "Inf", false, true},
+		{"Infinity", "Infinity", false, true},
+		{"1e400", "1e400", false, true},
+		{"0x1p-2", "0x1p-2", false, true},
+		{"+1", "+1", false, true},
+		{".5", ".5", false, true},
+		{"my value", "my value", false, true},
+		{"x' or 'a'='a", "", false, false},
+		{`x\`, "", false, false},
+		{math.NaN(), "", false, false},
+		{math.Inf(1), "", false, false},
+		{float32(math.Inf(-1)), "", false, false},
+	} {
+		lit, numeric, err := Params{"m": c.value}.literal("m")
+		if !c.valid {
+			assert.NotNil(t, err, "%v", c.value)
+			assert.Equal(t, "aver: invalid value for parameter 'm'", err.Error())
+			continue
+		}
+		assert.Nil(t, err, "%v", c.value)
+		assert.Equal(t, c.literal, lit, "%v", c.value)
+		assert.Equal(t, c.numeric, numeric, "%v", c.value)
+	}
+
+	// strings that aren't numbers are compared as such
+	db := openDB(t, ":memory:")
+	defer db.Close()
+	loadTestTable(t, db)
+
+	_, err := Holds("expect throughput(method=:m) > throughput(method='raw')",
+		db, "metrics", Params{"m": "nan"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no values associated to left-side predicates", err.Error())
+
+	_, err = Holds("expect throughput > $min", db, "metrics", Params{"min": "Inf"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: expecting numeric value for parameter 'min'", err.Error())
+}
+
+func TestUnboundAndUnusedParams(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadTestTable(t, db)
+
+	_, err := Holds(parameterized, db, "metrics", Params{
+		"min_size": 3, "method": "ceph", "factor": 0.9})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: unbound parameter 'baseline'", err.Error())
+
+	_, err = Holds(validation, db, "metrics", Params{"factor": 0.9, "bar": 1})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: unused parameter(s) 'bar', 'factor'", err.Error())
+
+	_, err = Holds(parameterized, db, "metrics", Params{
+		"min_size": 3, "method": "ceph", "baseline": "raw", "factor": "x"})
+
+	assert.NotNil(t, err)
+	assert.Equal(t,
+		"aver: expecting numeric value for parameter 'factor'", err.Error())
+}
+
+func TestValidationWithParams(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadTestTable(t, db)
+
+	params := Params{"min_size": 3, "method": "ceph", "baseline": "raw"}
+
+	params["factor"] = 0.9
+	holds, err := Holds(parameterized, db, "metrics", params)
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+
+	params["factor"] = 0.95
+	holds, err = Holds(parameterized, db, "metrics", params)
+
+	assert.Nil(t, err)
+	assert.False(t, holds)
+
+	assert.Nil(t, Check(parameterized, db, "metrics", params))
+}
+
+func TestParseParam(t *testing.T) {
+	name, value, err := ParseParam("factor=2")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "factor", name)
+	assert.Equal(t, "2", value)
+
+	_, _, err = ParseParam("factor")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,96 @@
+package aver
+
+import (
+	"strings"
+)
+
+// Domination is a violation of a Pareto statement: a configuration that is
+// expected to be Pareto-optimal but that is dominated by another one
+type Domination struct {
+	// Group is the 'for each' group the result belongs to (if any)
+	Group string
+	// Dominated and Dominating are the conjunctions of predicates that
+	// identify each configuration, e.g. "method='a' and size=4"
+	Dominated  string
+	Dominating string
+}
+
+// evaluates a Pareto statement. Every row selected by the left-side
+// predicates has to be Pareto-optimal with respect to the rows selected by the
+// right-side ones (or every row, if there are none), i.e. none of those can be
+// at least as good in every objective and strictly better in one of them. Rows
+// are identified by the values of the columns that are neither objectives nor
+// metrics, and those with missing objectives are ignored.
+func (v Validation) pareto(ds Dataset) (
+	holds bool, dominations []Domination, err error) {
+
+	for _, d := range v.directions {
+		if d != "max" && d != "min" {
+			return false, nil, AverError{"unknown direction " + d}
+		}
+	}
+
+	columns, err := ds.Schema()
+	if err != nil {
+		return
+	}
+	isObjective := make(map[string]bool)
+	for _, o := range v.objectives {
+		isObjective[strings.ToLower(o)] = true
+	}
+	metrics := metricsOf(ds)
+	keys := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if !isObjective[strings.ToLower(c)] && !metrics[strings.ToLower(c)] {
+			keys = append(keys, c)
+		}
+	}
+
+	candidates, err := selectPoints(
+		ds, keys, v.objectives, v.left.terms, v.globalTerms)
+	if err != nil {
+		return
+	}
+	candidates = present(candidates)
+	if len(candidates) == 0 {
+		return false, nil, AverError{"no values associated to left-side predicates"}
+	}
+	rivals, err := selectPoints(
+		ds, keys, v.objectives, v.right.terms, v.globalTerms)
+	if err != nil {
+		return
+	}
+	rivals = present(rivals)
+	if len(rivals) == 0 {
+		return false, nil, AverError{"no values associated to right-side predicates"}
+	}
+
+	for _, c := range candidates {
+		for _, r := range rivals {
+			if v.paretoDominates(r.values, c.values) {
+				dominations = append(dominations,
+					Domination{Dominated: conjunction(c.terms), Dominating: conjunction(r.terms)})
+			}
+		}
+	}
+	return len(dominations) == 0, dominations, nil
+}
+
+// whether the objective values in a are at least as good as the ones in b, and
+// strictly better for at least one of them
+func (v Validation) paretoDominates(a, b []float64) bool {
+	better := false
+	for i, d := range v.directions {
+		x, y := a[i], b[i]
+		if d == "min" {
+			x, y = -x, -y
+		}
+		if x < y {
+			return false
+		}
+		if x > y {
+			better = true
+		}
+	}
+	return better
+}
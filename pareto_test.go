@@ -0,0 +1,107 @@
+package aver
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTradeoffTable(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(`
+		CREATE TABLE tradeoffs (
+			method VARCHAR(255),
+			batch INT,
+			throughput FLOAT,
+			latency FLOAT
+		)
+	`)
+	assert.Nil(t, err)
+
+	for _, row := range []string{
+		"'a', 1, 100, 10", "'a', 8, 400, 30",
+		"'b', 1, 90, 12", "'b', 8, 300, 25",
+		"'c', 1, 120, 9", "'c', 8, 350, 40",
+	} {
+		_, err = db.Exec("INSERT INTO tradeoffs VALUES(" + row + ")")
+		assert.Nil(t, err)
+	}
+}
+
+func TestPareto(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadTradeoffTable(t, db)
+
+	r, err := Evaluate(
+		"expect pareto(throughput max, latency min)(method='b')", db, "tradeoffs")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []Domination{
+		{"", "method='b' and batch=1", "method='a' and batch=1"},
+		{"", "method='b' and batch=1", "method='c' and batch=1"},
+	}, r.Dominations)
+
+	holds, err := Holds(
+		"expect pareto(throughput max, latency min)(method='a' and batch=8)", db, "tradeoffs")
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+
+	// 'a' at batch 1 is dominated by 'c', but not by 'b'
+	holds, err = Holds(`
+	expect
+	  pareto(throughput max, latency min)(method='a') not dominated by (method='b')
+	`, db, "tradeoffs")
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+
+	r, err = Evaluate(`
+	expect
+	  pareto(throughput max, latency min)(method='a') not dominated by (method=*other*)
+	`, db, "tradeoffs")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []PairResult{
+		{"", "method='a'", "method='b'", true},
+		{"", "method='a'", "method='c'", false},
+	}, r.Pairs)
+
+	r, err = Evaluate(`
+	for each batch
+	expect
+	  pareto(throughput max)(method='c')
+	`, db, "tradeoffs")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []GroupResult{{"batch=1", true}, {"batch=8", false}}, r.Groups)
+	assert.Equal(t, "batch=8", r.Dominations[0].Group)
+	assert.Equal(t, "method='a' and batch=8 and latency=30", r.Dominations[0].Dominating)
+
+	// objectives are columns, whose names are case-insensitive
+	r, err = Evaluate(
+		"expect pareto(Throughput max, LATENCY min)(method='b')", db, "tradeoffs")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []Domination{
+		{"", "method='b' and batch=1", "method='a' and batch=1"},
+		{"", "method='b' and batch=1", "method='c' and batch=1"},
+	}, r.Dominations)
+}
+
+func TestParetoErrors(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadTradeoffTable(t, db)
+
+	_, err := Holds("expect pareto(throughput max)(method='d')", db, "tradeoffs")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no values associated to left-side predicates", err.Error())
+}
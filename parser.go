@@ -2,26 +2,133 @@
 
 package aver
 
-import "strings"
+import (
+	"io/ioutil"
+	"strings"
+)
 
 type Value struct {
 	funcName   string
 	predicates string
+	terms      []predicate
+}
+
+// a single '<column> <op> <literal>' term of a conjunction of predicates
+type predicate struct {
+	column  string
+	op      string
+	literal string
+	quoted  bool
+	param   bool
+	// true for '*other*', which stands for every value of the column other
+	// than the one given for it on the left side of the comparison
+	other bool
+}
+
+func (p predicate) String() string {
+	return p.column + p.op + p.sqlLiteral()
+}
+
+func (p predicate) sqlLiteral() string {
+	if p.other {
+		return "*other*"
+	}
+	if p.quoted {
+		return "'" + p.literal + "'"
+	}
+	return p.literal
 }
 
 type Validation struct {
-	global   string
-	left     Value
-	op       string
-	right    Value
-	relative string
+	// Label and Description are taken from the optional 'claim' header that
+	// precedes a statement, e.g. `claim "ceph-overhead": "ceph is within 10%"`
+	Label       string
+	Description string
+
+	global      string
+	globalTerms []predicate
+	groupBy     []string
+	left        Value
+	op          string
+	right       Value
+	relative    string
+
+	// for 'rank <var> by <column>: <value> <op> <value> ...' statements, the
+	// values given for the column (as equality predicates) and the operators
+	// between each consecutive pair of values
+	ranking []predicate
+	rankOps []string
+
+	// for variability statements such as 'cv(<var>(<predicates>)) < 0.05',
+	// the name of the statistic; the threshold is kept in right.funcName
+	statistic string
+
+	// for distribution statements, the test applied to the values of each
+	// side ('dominates' or 'ks') and, for the latter, the significance level
+	distribution string
+	significance string
+
+	// for correlation statements such as 'corr(<var>, <covariate>) > 0.9',
+	// the correlation coefficient ('corr', 'spearman' or 'kendall'); the
+	// first column is kept in left.funcName and the threshold in
+	// right.funcName
+	correlation string
+	covariate   string
+
+	// for scalability statements such as
+	// 'speedup(<var>, <column>, baseline=1) >= 0.8 * <column>', the function
+	// ('speedup' or 'efficiency'), the column that is scaled, its baseline
+	// value and the (optional) column that multiplies the threshold
+	scaling         string
+	scaleColumn     string
+	baseline        string
+	thresholdColumn string
+
+	// for ratio statements such as
+	// '<var>(<predicates>) / <var>(<predicates>) between 1.8 and 2.2', the
+	// bounds of the ratio
+	lower string
+	upper string
+
+	// for log-scale comparisons, either the factor of '<op> ... by 10x' or
+	// the number of orders of magnitude of 'within 1 order of magnitude of'
+	factor string
+	orders string
+
+	// for Pareto statements such as
+	// 'pareto(throughput max, latency min)(<predicates>)', the columns to
+	// optimize and the direction of each ('max' or 'min'). The rows that are
+	// expected to be Pareto-optimal are selected by the left-side predicates;
+	// the ones they are compared against by the (optional) right-side ones
+	objectives []string
+	directions []string
+
+	// for statements with an 'excluding outliers by <method> <threshold>'
+	// clause, the method used to detect outliers ('iqr', 'zscore' or 'mad')
+	// and its threshold
+	outliers         string
+	outlierThreshold string
+
+	// policy for missing values given with 'on missing (fail|skip|error)'
+	missing string
+
+	// for statements with a 'matching <column> within <tolerance>[%]' or an
+	// 'interpolate on <column>' clause, the column on which both sides are
+	// joined approximately
+	matchColumn       string
+	tolerance         string
+	relativeTolerance bool
+	interpolate       bool
 }
 
 type state struct {
-	currentPredicates string
-	currentString     string
-	currentValue      Value
-	validation        Validation
+	currentPredicates  string
+	currentPredicate   predicate
+	currentConjunction []predicate
+	currentString      string
+	currentValue       Value
+	validation         Validation
+	validations        []Validation
 }
 
 func ParseValidation(input string) (v Validation, e error) {
@@ -35,11 +142,56 @@ func ParseValidation(input string) (v Validation, e error) {
 
 	p.Execute()
 
-	return p.validation, nil
+	return p.validations[0], nil
+}
+
+// ParseValidations parses a sequence of validation statements, as found in
+// '.aver' files, where each statement can optionally be preceded by a claim
+// label and description.
+func ParseValidations(input string) (vs []Validation, e error) {
+	p := validationParser{Buffer: input}
+
+	p.Init()
+
+	if e = p.Parse(int(rulestatements)); e != nil {
+		return
+	}
+
+	p.Execute()
+
+	return p.validations, nil
+}
+
+// ParseValidationFile parses the statements contained in an '.aver' file
+func ParseValidationFile(file string) (vs []Validation, e error) {
+	b, e := ioutil.ReadFile(file)
+	if e != nil {
+		return
+	}
+	return ParseValidations(string(b))
+}
+
+func (s *state) EndStatement() {
+	s.validations = append(s.validations, s.validation)
+	s.validation = Validation{}
+}
+
+func (s *state) SetLabel(label string) {
+	s.validation.Label = label
+}
+
+func (s *state) SetDescription(description string) {
+	s.validation.Description = description
 }
 
 func (s *state) EndGlobalPredicates() {
 	s.validation.global = s.currentPredicates
+	s.validation.globalTerms = s.currentConjunction
+	s.currentPredicates, s.currentConjunction = "", nil
+}
+
+func (s *state) AddGroupColumn() {
+	s.validation.groupBy = append(s.validation.groupBy, s.currentString)
 }
 
 func (s *state) BeginFunctionValue() {
@@ -48,10 +200,179 @@ func (s *state) BeginFunctionValue() {
 
 func (s *state) EndFunctionValue() {
 	s.currentValue.predicates = s.currentPredicates
+	s.currentValue.terms = s.currentConjunction
+	s.currentPredicates, s.currentConjunction = "", nil
+}
+
+// predicates are rebuilt from their parsed components rather than taken
+// verbatim from the input, so that comments never make it into a query
+func (s *state) BeginPredicates() {
+	s.currentConjunction = nil
+}
+
+func (s *state) EndPredicates() {
+	s.currentPredicates = conjunction(s.currentConjunction)
+}
+
+func conjunction(predicates []predicate) string {
+	terms := make([]string, len(predicates))
+	for i, p := range predicates {
+		terms[i] = p.String()
+	}
+	return strings.Join(terms, " and ")
+}
+
+func (s *state) BeginPredicate() {
+	s.currentPredicate = predicate{column: s.currentString}
+}
+
+func (s *state) SetPredicateOp(op string) {
+	s.currentPredicate.op = strings.TrimSpace(op)
+}
+
+func (s *state) EndNumericPredicate() {
+	s.currentPredicate.literal = s.currentString
+	s.currentConjunction = append(s.currentConjunction, s.currentPredicate)
+}
+
+func (s *state) EndStringPredicate() {
+	s.currentPredicate.literal = s.currentString
+	s.currentPredicate.quoted = true
+	s.currentConjunction = append(s.currentConjunction, s.currentPredicate)
+}
+
+func (s *state) EndParamPredicate() {
+	s.currentPredicate.literal = s.currentString
+	s.currentPredicate.param = true
+	s.currentConjunction = append(s.currentConjunction, s.currentPredicate)
+}
+
+func (s *state) EndOtherPredicate() {
+	s.currentPredicate.other = true
+	s.currentConjunction = append(s.currentConjunction, s.currentPredicate)
+}
+
+func (s *state) BeginRanking() {
+	s.validation.left = Value{funcName: s.currentString}
+	s.validation.right = Value{funcName: s.currentString}
+}
+
+func (s *state) SetRankingColumn() {
+	s.currentPredicate = predicate{column: s.currentString, op: "="}
+}
+
+func (s *state) AddRankingValue(quoted bool) {
+	value := s.currentPredicate
+	value.literal, value.quoted = s.currentString, quoted
+	s.validation.ranking = append(s.validation.ranking, value)
+}
+
+func (s *state) AddRankingOp(op string) {
+	s.validation.rankOps = append(s.validation.rankOps, strings.TrimSpace(op))
+}
+
+func (s *state) SetStatistic(statistic string) {
+	s.validation.statistic = statistic
+}
+
+func (s *state) SetThreshold() {
+	s.validation.right = Value{funcName: s.currentString}
+}
+
+func (s *state) SetScaling(function string) {
+	s.validation.scaling = function
+}
+
+func (s *state) SetScaleColumn() {
+	s.validation.scaleColumn = s.currentString
+}
+
+func (s *state) SetBaseline() {
+	s.validation.baseline = s.currentString
+}
+
+func (s *state) SetThresholdColumn() {
+	s.validation.thresholdColumn = s.currentString
+}
+
+func (s *state) SetLowerBound() {
+	s.validation.lower = s.currentString
+}
+
+func (s *state) SetUpperBound() {
+	s.validation.upper = s.currentString
+}
+
+func (s *state) SetFactor() {
+	s.validation.factor = s.currentString
+}
+
+func (s *state) SetOrders() {
+	s.validation.orders = s.currentString
+}
+
+func (s *state) AddObjective(direction string) {
+	s.validation.objectives = append(s.validation.objectives, s.currentString)
+	s.validation.directions = append(s.validation.directions, direction)
+}
+
+func (s *state) EndParetoCandidates() {
+	s.validation.left = Value{predicates: s.currentPredicates, terms: s.currentConjunction}
+	s.currentPredicates, s.currentConjunction = "", nil
+}
+
+func (s *state) EndParetoRivals() {
+	s.validation.right = Value{predicates: s.currentPredicates, terms: s.currentConjunction}
+	s.currentPredicates, s.currentConjunction = "", nil
+}
+
+func (s *state) SetOutlierMethod(method string) {
+	s.validation.outliers = method
+}
+
+func (s *state) SetOutlierThreshold() {
+	s.validation.outlierThreshold = s.currentString
+}
+
+func (s *state) SetMissingPolicy(policy string) {
+	s.validation.missing = policy
+}
+
+func (s *state) SetMatchColumn() {
+	s.validation.matchColumn = s.currentString
+}
+
+func (s *state) SetTolerance() {
+	s.validation.tolerance = s.currentString
+}
+
+func (s *state) SetRelativeTolerance() {
+	s.validation.relativeTolerance = true
+}
+
+func (s *state) SetInterpolation() {
+	s.validation.matchColumn = s.currentString
+	s.validation.interpolate = true
+}
+
+func (s *state) SetDistributionTest(test string) {
+	s.validation.distribution = test
+}
+
+func (s *state) SetSignificance() {
+	s.validation.significance = s.currentString
+}
+
+func (s *state) SetCorrelation(coefficient string) {
+	s.validation.correlation = coefficient
+}
+
+func (s *state) SetVariable() {
+	s.validation.left = Value{funcName: s.currentString}
 }
 
-func (s *state) SetPredicates(predicates string) {
-	s.currentPredicates = strings.TrimSpace(predicates)
+func (s *state) SetCovariate() {
+	s.validation.covariate = s.currentString
 }
 
 func (s *state) EndLeft() {
@@ -15,20 +15,40 @@ type pegRule uint8
 const (
 	ruleUnknown pegRule = iota
 	ruleexpression
+	rulestatements
+	rulestatement
+	ruleclaim
 	ruleglobal_predicates
+	rulegrouping
 	rulepredicates
+	rulejoin
+	ruleoutliers
+	rulemissing
 	rulevalidation
+	rulevariability
+	rulecorrelation
+	rulescaling
+	rulepareto
+	ruleobjective
+	ruleratio
+	rulemagnitude
+	ruledistribution
+	ruleranking
+	rulerank_value
 	ruleresult
 	rulevalue
 	ruleop
 	rulepredicate
 	ruleliteral
 	rulerelative
+	rulefactor
 	rulestr
 	rulenumber
+	ruleparam
+	rulequoted
 	rulews
+	rulecomment
 	ruleAction0
-	rulePegText
 	ruleAction1
 	ruleAction2
 	ruleAction3
@@ -38,6 +58,66 @@ const (
 	ruleAction7
 	ruleAction8
 	ruleAction9
+	ruleAction10
+	ruleAction11
+	rulePegText
+	ruleAction12
+	ruleAction13
+	ruleAction14
+	ruleAction15
+	ruleAction16
+	ruleAction17
+	ruleAction18
+	ruleAction19
+	ruleAction20
+	ruleAction21
+	ruleAction22
+	ruleAction23
+	ruleAction24
+	ruleAction25
+	ruleAction26
+	ruleAction27
+	ruleAction28
+	ruleAction29
+	ruleAction30
+	ruleAction31
+	ruleAction32
+	ruleAction33
+	ruleAction34
+	ruleAction35
+	ruleAction36
+	ruleAction37
+	ruleAction38
+	ruleAction39
+	ruleAction40
+	ruleAction41
+	ruleAction42
+	ruleAction43
+	ruleAction44
+	ruleAction45
+	ruleAction46
+	ruleAction47
+	ruleAction48
+	ruleAction49
+	ruleAction50
+	ruleAction51
+	ruleAction52
+	ruleAction53
+	ruleAction54
+	ruleAction55
+	ruleAction56
+	ruleAction57
+	ruleAction58
+	ruleAction59
+	ruleAction60
+	ruleAction61
+	ruleAction62
+	ruleAction63
+	ruleAction64
+	ruleAction65
+	ruleAction66
+	ruleAction67
+	ruleAction68
 
 	rulePre_
 	rule_In_
@@ -47,20 +127,40 @@ const (
 var rul3s = [...]string{
 	"Unknown",
 	"expression",
+	"statements",
+	"statement",
+	"claim",
 	"global_predicates",
+	"grouping",
 	"predicates",
+	"join",
+	"outliers",
+	"missing",
 	"validation",
+	"variability",
+	"correlation",
+	"scaling",
+	"pareto",
+	"objective",
+	"ratio",
+	"magnitude",
+	"distribution",
+	"ranking",
+	"rank_value",
 	"result",
 	"value",
 	"op",
 	"predicate",
 	"literal",
 	"relative",
+	"factor",
 	"str",
 	"number",
+	"param",
+	"quoted",
 	"ws",
+	"comment",
 	"Action0",
-	"PegText",
 	"Action1",
 	"Action2",
 	"Action3",
@@ -70,6 +170,66 @@ var rul3s = [...]string{
 	"Action7",
 	"Action8",
 	"Action9",
+	"Action10",
+	"Action11",
+	"PegText",
+	"Action12",
+	"Action13",
+	"Action14",
+	"Action15",
+	"Action16",
+	"Action17",
+	"Action18",
+	"Action19",
+	"Action20",
+	"Action21",
+	"Action22",
+	"Action23",
+	"Action24",
+	"Action25",
+	"Action26",
+	"Action27",
+	"Action28",
+	"Action29",
+	"Action30",
+	"Action31",
+	"Action32",
+	"Action33",
+	"Action34",
+	"Action35",
+	"Action36",
+	"Action37",
+	"Action38",
+	"Action39",
+	"Action40",
+	"Action41",
+	"Action42",
+	"Action43",
+	"Action44",
+	"Action45",
+	"Action46",
+	"Action47",
+	"Action48",
+	"Action49",
+	"Action50",
+	"Action51",
+	"Action52",
+	"Action53",
+	"Action54",
+	"Action55",
+	"Action56",
+	"Action57",
+	"Action58",
+	"Action59",
+	"Action60",
+	"Action61",
+	"Action62",
+	"Action63",
+	"Action64",
+	"Action65",
+	"Action66",
+	"Action67",
+	"Action68",
 
 	"Pre_",
 	"_In_",
@@ -390,7 +550,7 @@ type validationParser struct {
 
 	Buffer string
 	buffer []rune
-	rules  [25]func() bool
+	rules  [105]func() bool
 	Parse  func(rule ...int) error
 	Reset  func()
 	tokenTree
@@ -468,24 +628,142 @@ func (p *validationParser) Execute() {
 			begin, end = int(token.begin), int(token.end)
 
 		case ruleAction0:
-			p.EndGlobalPredicates()
+			p.EndStatement()
 		case ruleAction1:
-			p.SetPredicates(buffer[begin:end])
+			p.SetLabel(buffer[begin:end])
 		case ruleAction2:
-			p.EndLeft()
+			p.SetDescription(buffer[begin:end])
 		case ruleAction3:
-			p.SetResultOp(buffer[begin:end])
+			p.EndGlobalPredicates()
 		case ruleAction4:
-			p.EndRight()
+			p.AddGroupColumn()
 		case ruleAction5:
-			p.BeginFunctionValue()
+			p.AddGroupColumn()
 		case ruleAction6:
-			p.EndFunctionValue()
+			p.BeginPredicates()
 		case ruleAction7:
-			p.SetRelative()
+			p.EndPredicates()
 		case ruleAction8:
-			p.StringValue(buffer[begin:end])
+			p.SetMatchColumn()
 		case ruleAction9:
+			p.SetTolerance()
+		case ruleAction10:
+			p.SetRelativeTolerance()
+		case ruleAction11:
+			p.SetInterpolation()
+		case ruleAction12:
+			p.SetOutlierMethod(buffer[begin:end])
+		case ruleAction13:
+			p.SetOutlierThreshold()
+		case ruleAction14:
+			p.SetMissingPolicy(buffer[begin:end])
+		case ruleAction15:
+			p.SetStatistic(buffer[begin:end])
+		case ruleAction16:
+			p.EndLeft()
+		case ruleAction17:
+			p.SetResultOp(buffer[begin:end])
+		case ruleAction18:
+			p.SetThreshold()
+		case ruleAction19:
+			p.SetCorrelation(buffer[begin:end])
+		case ruleAction20:
+			p.SetVariable()
+		case ruleAction21:
+			p.SetCovariate()
+		case ruleAction22:
+			p.SetResultOp(buffer[begin:end])
+		case ruleAction23:
+			p.SetThreshold()
+		case ruleAction24:
+			p.SetScaling(buffer[begin:end])
+		case ruleAction25:
+			p.SetVariable()
+		case ruleAction26:
+			p.SetScaleColumn()
+		case ruleAction27:
+			p.SetBaseline()
+		case ruleAction28:
+			p.SetResultOp(buffer[begin:end])
+		case ruleAction29:
+			p.SetThreshold()
+		case ruleAction30:
+			p.SetThresholdColumn()
+		case ruleAction31:
+			p.EndParetoCandidates()
+		case ruleAction32:
+			p.EndParetoRivals()
+		case ruleAction33:
+			p.AddObjective(buffer[begin:end])
+		case ruleAction34:
+			p.EndLeft()
+		case ruleAction35:
+			p.EndRight()
+		case ruleAction36:
+			p.SetLowerBound()
+		case ruleAction37:
+			p.SetUpperBound()
+		case ruleAction38:
+			p.EndLeft()
+		case ruleAction39:
+			p.SetOrders()
+		case ruleAction40:
+			p.EndRight()
+		case ruleAction41:
+			p.EndLeft()
+		case ruleAction42:
+			p.SetDistributionTest("dominates")
+		case ruleAction43:
+			p.EndRight()
+		case ruleAction44:
+			p.SetDistributionTest("ks")
+		case ruleAction45:
+			p.EndRight()
+		case ruleAction46:
+			p.SetSignificance()
+		case ruleAction47:
+			p.BeginRanking()
+		case ruleAction48:
+			p.SetRankingColumn()
+		case ruleAction49:
+			p.AddRankingOp(buffer[begin:end])
+		case ruleAction50:
+			p.AddRankingValue(true)
+		case ruleAction51:
+			p.AddRankingValue(false)
+		case ruleAction52:
+			p.AddRankingValue(true)
+		case ruleAction53:
+			p.EndLeft()
+		case ruleAction54:
+			p.SetResultOp(buffer[begin:end])
+		case ruleAction55:
+			p.EndRight()
+		case ruleAction56:
+			p.BeginFunctionValue()
+		case ruleAction57:
+			p.EndFunctionValue()
+		case ruleAction58:
+			p.BeginPredicate()
+		case ruleAction59:
+			p.SetPredicateOp(buffer[begin:end])
+		case ruleAction60:
+			p.EndNumericPredicate()
+		case ruleAction61:
+			p.EndStringPredicate()
+		case ruleAction62:
+			p.EndParamPredicate()
+		case ruleAction63:
+			p.EndOtherPredicate()
+		case ruleAction64:
+			p.SetRelative()
+		case ruleAction65:
+			p.SetFactor()
+		case ruleAction66:
+			p.StringValue(buffer[begin:end])
+		case ruleAction67:
+			p.StringValue(buffer[begin:end])
+		case ruleAction68:
 			p.StringValue(buffer[begin:end])
 
 		}
@@ -554,143 +832,26 @@ func (p *validationParser) Init() {
 
 	_rules = [...]func() bool{
 		nil,
-		/* 0 expression <- <(global_predicates? validation !.)> */
+		/* 0 expression <- <(statement ws !.)> */
 		func() bool {
 			position0, tokenIndex0, depth0 := position, tokenIndex, depth
 			{
 				position1 := position
 				depth++
-				{
-					position2, tokenIndex2, depth2 := position, tokenIndex, depth
-					{
-						position4 := position
-						depth++
-						if !_rules[rulews]() {
-							goto l2
-						}
-						if buffer[position] != rune('f') {
-							goto l2
-						}
-						position++
-						if buffer[position] != rune('o') {
-							goto l2
-						}
-						position++
-						if buffer[position] != rune('r') {
-							goto l2
-						}
-						position++
-						if !_rules[rulepredicates]() {
-							goto l2
-						}
-						{
-							add(ruleAction0, position)
-						}
-						depth--
-						add(ruleglobal_predicates, position4)
-					}
-					goto l3
-				l2:
-					position, tokenIndex, depth = position2, tokenIndex2, depth2
+				if !_rules[rulestatement]() {
+					goto l0
 				}
-			l3:
-				{
-					position6 := position
-					depth++
-					if !_rules[rulews]() {
-						goto l0
-					}
-					if buffer[position] != rune('e') {
-						goto l0
-					}
-					position++
-					if buffer[position] != rune('x') {
-						goto l0
-					}
-					position++
-					if buffer[position] != rune('p') {
-						goto l0
-					}
-					position++
-					if buffer[position] != rune('e') {
-						goto l0
-					}
-					position++
-					if buffer[position] != rune('c') {
-						goto l0
-					}
-					position++
-					if buffer[position] != rune('t') {
-						goto l0
-					}
-					position++
-					{
-						position7 := position
-						depth++
-						if !_rules[rulevalue]() {
-							goto l0
-						}
-						{
-							add(ruleAction2, position)
-						}
-						{
-							position9 := position
-							depth++
-							if !_rules[ruleop]() {
-								goto l0
-							}
-							depth--
-							add(rulePegText, position9)
-						}
-						{
-							add(ruleAction3, position)
-						}
-						if !_rules[rulevalue]() {
-							goto l0
-						}
-						{
-							add(ruleAction4, position)
-						}
-						{
-							position12, tokenIndex12, depth12 := position, tokenIndex, depth
-							{
-								position14 := position
-								depth++
-								if !_rules[rulews]() {
-									goto l12
-								}
-								if buffer[position] != rune('*') {
-									goto l12
-								}
-								position++
-								if !_rules[rulenumber]() {
-									goto l12
-								}
-								{
-									add(ruleAction7, position)
-								}
-								depth--
-								add(rulerelative, position14)
-							}
-							goto l13
-						l12:
-							position, tokenIndex, depth = position12, tokenIndex12, depth12
-						}
-					l13:
-						depth--
-						add(ruleresult, position7)
-					}
-					depth--
-					add(rulevalidation, position6)
+				if !_rules[rulews]() {
+					goto l0
 				}
 				{
-					position16, tokenIndex16, depth16 := position, tokenIndex, depth
+					position2, tokenIndex2, depth2 := position, tokenIndex, depth
 					if !matchDot() {
-						goto l16
+						goto l2
 					}
 					goto l0
-				l16:
-					position, tokenIndex, depth = position16, tokenIndex16, depth16
+				l2:
+					position, tokenIndex, depth = position2, tokenIndex2, depth2
 				}
 				depth--
 				add(ruleexpression, position1)
@@ -700,471 +861,3324 @@ func (p *validationParser) Init() {
 			position, tokenIndex, depth = position0, tokenIndex0, depth0
 			return false
 		},
-		/* 1 global_predicates <- <(ws ('f' 'o' 'r') predicates Action0)> */
-		nil,
-		/* 2 predicates <- <(<(predicate ('a' 'n' 'd' predicate)*)> Action1)> */
+		/* 1 statements <- <(statement* ws !.)> */
 		func() bool {
-			position18, tokenIndex18, depth18 := position, tokenIndex, depth
+			position3, tokenIndex3, depth3 := position, tokenIndex, depth
 			{
-				position19 := position
+				position4 := position
 				depth++
+			l5:
 				{
-					position20 := position
-					depth++
-					if !_rules[rulepredicate]() {
-						goto l18
-					}
-				l21:
-					{
-						position22, tokenIndex22, depth22 := position, tokenIndex, depth
-						if buffer[position] != rune('a') {
-							goto l22
-						}
-						position++
-						if buffer[position] != rune('n') {
-							goto l22
-						}
-						position++
-						if buffer[position] != rune('d') {
-							goto l22
-						}
-						position++
-						if !_rules[rulepredicate]() {
-							goto l22
-						}
-						goto l21
-					l22:
-						position, tokenIndex, depth = position22, tokenIndex22, depth22
+					position6, tokenIndex6, depth6 := position, tokenIndex, depth
+					if !_rules[rulestatement]() {
+						goto l6
 					}
-					depth--
-					add(rulePegText, position20)
-				}
-				{
-					add(ruleAction1, position)
-				}
-				depth--
-				add(rulepredicates, position19)
-			}
-			return true
-		l18:
-			position, tokenIndex, depth = position18, tokenIndex18, depth18
-			return false
-		},
-		/* 3 validation <- <(ws ('e' 'x' 'p' 'e' 'c' 't') result)> */
-		nil,
-		/* 4 result <- <(value Action2 <op> Action3 value Action4 relative?)> */
-		nil,
-		/* 5 value <- <(str ws Action5 ('(' predicates ')' ws)? Action6)> */
-		func() bool {
-			position26, tokenIndex26, depth26 := position, tokenIndex, depth
-			{
-				position27 := position
-				depth++
-				if !_rules[rulestr]() {
-					goto l26
+					goto l5
+				l6:
+					position, tokenIndex, depth = position6, tokenIndex6, depth6
 				}
 				if !_rules[rulews]() {
-					goto l26
-				}
-				{
-					add(ruleAction5, position)
+					goto l3
 				}
 				{
-					position29, tokenIndex29, depth29 := position, tokenIndex, depth
-					if buffer[position] != rune('(') {
-						goto l29
-					}
-					position++
-					if !_rules[rulepredicates]() {
-						goto l29
-					}
-					if buffer[position] != rune(')') {
-						goto l29
-					}
-					position++
-					if !_rules[rulews]() {
-						goto l29
+					position7, tokenIndex7, depth7 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l7
 					}
-					goto l30
-				l29:
-					position, tokenIndex, depth = position29, tokenIndex29, depth29
-				}
-			l30:
-				{
-					add(ruleAction6, position)
+					goto l3
+				l7:
+					position, tokenIndex, depth = position7, tokenIndex7, depth7
 				}
 				depth--
-				add(rulevalue, position27)
+				add(rulestatements, position4)
 			}
 			return true
-		l26:
-			position, tokenIndex, depth = position26, tokenIndex26, depth26
+		l3:
+			position, tokenIndex, depth = position3, tokenIndex3, depth3
 			return false
 		},
-		/* 6 op <- <(ws ('>' / '<' / ('<' '=') / ((&('<') ('<' '>')) | (&('>') ('>' '=')) | (&('=') '='))))> */
+		/* 2 statement <- <(claim? ((grouping global_predicates?) / (global_predicates grouping?))? validation join? outliers? missing? Action0)> */
 		func() bool {
-			position32, tokenIndex32, depth32 := position, tokenIndex, depth
+			position8, tokenIndex8, depth8 := position, tokenIndex, depth
 			{
-				position33 := position
+				position9 := position
 				depth++
-				if !_rules[rulews]() {
-					goto l32
-				}
 				{
-					position34, tokenIndex34, depth34 := position, tokenIndex, depth
-					if buffer[position] != rune('>') {
-						goto l35
-					}
-					position++
-					goto l34
-				l35:
-					position, tokenIndex, depth = position34, tokenIndex34, depth34
-					if buffer[position] != rune('<') {
-						goto l36
-					}
-					position++
-					goto l34
-				l36:
-					position, tokenIndex, depth = position34, tokenIndex34, depth34
-					if buffer[position] != rune('<') {
-						goto l37
-					}
-					position++
-					if buffer[position] != rune('=') {
-						goto l37
-					}
-					position++
-					goto l34
-				l37:
-					position, tokenIndex, depth = position34, tokenIndex34, depth34
+					position10, tokenIndex10, depth10 := position, tokenIndex, depth
 					{
-						switch buffer[position] {
-						case '<':
-							if buffer[position] != rune('<') {
-								goto l32
-							}
-							position++
-							if buffer[position] != rune('>') {
-								goto l32
-							}
-							position++
-							break
-						case '>':
-							if buffer[position] != rune('>') {
-								goto l32
+						position12 := position
+						depth++
+						if !_rules[rulews]() {
+							goto l10
+						}
+						if buffer[position] != rune('c') {
+							goto l10
+						}
+						position++
+						if buffer[position] != rune('l') {
+							goto l10
+						}
+						position++
+						if buffer[position] != rune('a') {
+							goto l10
+						}
+						position++
+						if buffer[position] != rune('i') {
+							goto l10
+						}
+						position++
+						if buffer[position] != rune('m') {
+							goto l10
+						}
+						position++
+						if !_rules[rulews]() {
+							goto l10
+						}
+						if !_rules[rulequoted]() {
+							goto l10
+						}
+						{
+							add(ruleAction1, position)
+						}
+						if !_rules[rulews]() {
+							goto l10
+						}
+						if buffer[position] != rune(':') {
+							goto l10
+						}
+						position++
+						{
+							position13, tokenIndex13, depth13 := position, tokenIndex, depth
+							if !_rules[rulews]() {
+								goto l13
 							}
-							position++
-							if buffer[position] != rune('=') {
-								goto l32
+							if !_rules[rulequoted]() {
+								goto l13
 							}
-							position++
-							break
-						default:
-							if buffer[position] != rune('=') {
-								goto l32
+							{
+								add(ruleAction2, position)
 							}
-							position++
-							break
+							goto l14
+						l13:
+							position, tokenIndex, depth = position13, tokenIndex13, depth13
 						}
+					l14:
+						depth--
+						add(ruleclaim, position12)
 					}
-
-				}
-			l34:
-				depth--
-				add(ruleop, position33)
-			}
-			return true
-		l32:
-			position, tokenIndex, depth = position32, tokenIndex32, depth32
-			return false
-		},
-		/* 7 predicate <- <(str op literal)> */
-		func() bool {
-			position39, tokenIndex39, depth39 := position, tokenIndex, depth
-			{
-				position40 := position
-				depth++
-				if !_rules[rulestr]() {
-					goto l39
-				}
-				if !_rules[ruleop]() {
-					goto l39
+					goto l11
+				l10:
+					position, tokenIndex, depth = position10, tokenIndex10, depth10
 				}
+			l11:
 				{
-					position41 := position
-					depth++
-					if !_rules[rulews]() {
-						goto l39
-					}
+					position15, tokenIndex15, depth15 := position, tokenIndex, depth
 					{
-						position42, tokenIndex42, depth42 := position, tokenIndex, depth
-						if !_rules[rulenumber]() {
-							goto l43
+						position17, tokenIndex17, depth17 := position, tokenIndex, depth
+						if !_rules[rulegrouping]() {
+							goto l19
 						}
-						goto l42
-					l43:
-						position, tokenIndex, depth = position42, tokenIndex42, depth42
-						if buffer[position] != rune('\'') {
-							goto l39
+						{
+							position20, tokenIndex20, depth20 := position, tokenIndex, depth
+							if !_rules[ruleglobal_predicates]() {
+								goto l20
+							}
+							goto l21
+						l20:
+							position, tokenIndex, depth = position20, tokenIndex20, depth20
 						}
-						position++
-						if !_rules[rulestr]() {
-							goto l39
+					l21:
+						goto l18
+					l19:
+						position, tokenIndex, depth = position17, tokenIndex17, depth17
+						if !_rules[ruleglobal_predicates]() {
+							goto l15
 						}
-						if buffer[position] != rune('\'') {
-							goto l39
+						{
+							position22, tokenIndex22, depth22 := position, tokenIndex, depth
+							if !_rules[rulegrouping]() {
+								goto l22
+							}
+							goto l23
+						l22:
+							position, tokenIndex, depth = position22, tokenIndex22, depth22
 						}
-						position++
-					}
-				l42:
-					if !_rules[rulews]() {
-						goto l39
+					l23:
 					}
-					depth--
-					add(ruleliteral, position41)
-				}
-				depth--
-				add(rulepredicate, position40)
-			}
-			return true
-		l39:
-			position, tokenIndex, depth = position39, tokenIndex39, depth39
-			return false
-		},
-		/* 8 literal <- <(ws (number / ('\'' str '\'')) ws)> */
-		nil,
-		/* 9 relative <- <(ws '*' number Action7)> */
-		nil,
-		/* 10 str <- <(ws <(((&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])) ((&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))*)> ws Action8)> */
-		func() bool {
-			position46, tokenIndex46, depth46 := position, tokenIndex, depth
-			{
-				position47 := position
-				depth++
-				if !_rules[rulews]() {
-					goto l46
+				l18:
+					goto l16
+				l15:
+					position, tokenIndex, depth = position15, tokenIndex15, depth15
 				}
+			l16:
 				{
-					position48 := position
+					position24 := position
 					depth++
 					{
-						switch buffer[position] {
-						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l46
-							}
-							position++
-							break
-						case '_':
+						position25, tokenIndex25, depth25 := position, tokenIndex, depth
+						if !_rules[rulews]() {
+							goto l27
+						}
+						if buffer[position] != rune('e') {
+							goto l27
+						}
+						position++
+						if buffer[position] != rune('x') {
+							goto l27
+						}
+						position++
+						if buffer[position] != rune('p') {
+							goto l27
+						}
+						position++
+						if buffer[position] != rune('e') {
+							goto l27
+						}
+						position++
+						if buffer[position] != rune('c') {
+							goto l27
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l27
+						}
+						position++
+						{
+							position28, tokenIndex28, depth28 := position, tokenIndex, depth
+							{
+								position31 := position
+								depth++
+								if !_rules[rulews]() {
+									goto l30
+								}
+								{
+									position32 := position
+									depth++
+									{
+										position33, tokenIndex33, depth33 := position, tokenIndex, depth
+										if buffer[position] != rune('s') {
+											goto l35
+										}
+										position++
+										if buffer[position] != rune('t') {
+											goto l35
+										}
+										position++
+										if buffer[position] != rune('d') {
+											goto l35
+										}
+										position++
+										if buffer[position] != rune('d') {
+											goto l35
+										}
+										position++
+										if buffer[position] != rune('e') {
+											goto l35
+										}
+										position++
+										if buffer[position] != rune('v') {
+											goto l35
+										}
+										position++
+										goto l34
+									l35:
+										position, tokenIndex, depth = position33, tokenIndex33, depth33
+										if buffer[position] != rune('c') {
+											goto l36
+										}
+										position++
+										if buffer[position] != rune('v') {
+											goto l36
+										}
+										position++
+										goto l34
+									l36:
+										position, tokenIndex, depth = position33, tokenIndex33, depth33
+										if buffer[position] != rune('i') {
+											goto l37
+										}
+										position++
+										if buffer[position] != rune('q') {
+											goto l37
+										}
+										position++
+										if buffer[position] != rune('r') {
+											goto l37
+										}
+										position++
+										goto l34
+									l37:
+										position, tokenIndex, depth = position33, tokenIndex33, depth33
+										if buffer[position] != rune('m') {
+											goto l30
+										}
+										position++
+										if buffer[position] != rune('a') {
+											goto l30
+										}
+										position++
+										if buffer[position] != rune('d') {
+											goto l30
+										}
+										position++
+									}
+								l34:
+									depth--
+									add(rulePegText, position32)
+								}
+								if !_rules[rulews]() {
+									goto l30
+								}
+								if buffer[position] != rune('(') {
+									goto l30
+								}
+								position++
+								{
+									add(ruleAction15, position)
+								}
+								if !_rules[rulevalue]() {
+									goto l30
+								}
+								if buffer[position] != rune(')') {
+									goto l30
+								}
+								position++
+								if !_rules[rulews]() {
+									goto l30
+								}
+								{
+									add(ruleAction16, position)
+								}
+								{
+									position38 := position
+									depth++
+									if !_rules[ruleop]() {
+										goto l30
+									}
+									depth--
+									add(rulePegText, position38)
+								}
+								{
+									add(ruleAction17, position)
+								}
+								{
+									position39, tokenIndex39, depth39 := position, tokenIndex, depth
+									if !_rules[rulenumber]() {
+										goto l41
+									}
+									goto l40
+								l41:
+									position, tokenIndex, depth = position39, tokenIndex39, depth39
+									if !_rules[ruleparam]() {
+										goto l30
+									}
+								}
+							l40:
+								{
+									add(ruleAction18, position)
+								}
+								depth--
+								add(rulevariability, position31)
+							}
+							goto l29
+						l30:
+							position, tokenIndex, depth = position28, tokenIndex28, depth28
+							{
+								position43 := position
+								depth++
+								if !_rules[rulews]() {
+									goto l42
+								}
+								{
+									position44 := position
+									depth++
+									{
+										position45, tokenIndex45, depth45 := position, tokenIndex, depth
+										if buffer[position] != rune('c') {
+											goto l47
+										}
+										position++
+										if buffer[position] != rune('o') {
+											goto l47
+										}
+										position++
+										if buffer[position] != rune('r') {
+											goto l47
+										}
+										position++
+										if buffer[position] != rune('r') {
+											goto l47
+										}
+										position++
+										goto l46
+									l47:
+										position, tokenIndex, depth = position45, tokenIndex45, depth45
+										if buffer[position] != rune('s') {
+											goto l48
+										}
+										position++
+										if buffer[position] != rune('p') {
+											goto l48
+										}
+										position++
+										if buffer[position] != rune('e') {
+											goto l48
+										}
+										position++
+										if buffer[position] != rune('a') {
+											goto l48
+										}
+										position++
+										if buffer[position] != rune('r') {
+											goto l48
+										}
+										position++
+										if buffer[position] != rune('m') {
+											goto l48
+										}
+										position++
+										if buffer[position] != rune('a') {
+											goto l48
+										}
+										position++
+										if buffer[position] != rune('n') {
+											goto l48
+										}
+										position++
+										goto l46
+									l48:
+										position, tokenIndex, depth = position45, tokenIndex45, depth45
+										if buffer[position] != rune('k') {
+											goto l42
+										}
+										position++
+										if buffer[position] != rune('e') {
+											goto l42
+										}
+										position++
+										if buffer[position] != rune('n') {
+											goto l42
+										}
+										position++
+										if buffer[position] != rune('d') {
+											goto l42
+										}
+										position++
+										if buffer[position] != rune('a') {
+											goto l42
+										}
+										position++
+										if buffer[position] != rune('l') {
+											goto l42
+										}
+										position++
+										if buffer[position] != rune('l') {
+											goto l42
+										}
+										position++
+									}
+								l46:
+									depth--
+									add(rulePegText, position44)
+								}
+								if !_rules[rulews]() {
+									goto l42
+								}
+								if buffer[position] != rune('(') {
+									goto l42
+								}
+								position++
+								{
+									add(ruleAction19, position)
+								}
+								if !_rules[rulestr]() {
+									goto l42
+								}
+								{
+									add(ruleAction20, position)
+								}
+								if buffer[position] != rune(',') {
+									goto l42
+								}
+								position++
+								if !_rules[rulestr]() {
+									goto l42
+								}
+								{
+									add(ruleAction21, position)
+								}
+								if buffer[position] != rune(')') {
+									goto l42
+								}
+								position++
+								if !_rules[rulews]() {
+									goto l42
+								}
+								{
+									position49 := position
+									depth++
+									if !_rules[ruleop]() {
+										goto l42
+									}
+									depth--
+									add(rulePegText, position49)
+								}
+								{
+									add(ruleAction22, position)
+								}
+								{
+									position50, tokenIndex50, depth50 := position, tokenIndex, depth
+									if !_rules[rulenumber]() {
+										goto l52
+									}
+									goto l51
+								l52:
+									position, tokenIndex, depth = position50, tokenIndex50, depth50
+									if !_rules[ruleparam]() {
+										goto l42
+									}
+								}
+							l51:
+								{
+									add(ruleAction23, position)
+								}
+								depth--
+								add(rulecorrelation, position43)
+							}
+							goto l29
+						l42:
+							position, tokenIndex, depth = position28, tokenIndex28, depth28
+							{
+								position54 := position
+								depth++
+								if !_rules[rulews]() {
+									goto l53
+								}
+								{
+									position55 := position
+									depth++
+									{
+										position56, tokenIndex56, depth56 := position, tokenIndex, depth
+										if buffer[position] != rune('s') {
+											goto l58
+										}
+										position++
+										if buffer[position] != rune('p') {
+											goto l58
+										}
+										position++
+										if buffer[position] != rune('e') {
+											goto l58
+										}
+										position++
+										if buffer[position] != rune('e') {
+											goto l58
+										}
+										position++
+										if buffer[position] != rune('d') {
+											goto l58
+										}
+										position++
+										if buffer[position] != rune('u') {
+											goto l58
+										}
+										position++
+										if buffer[position] != rune('p') {
+											goto l58
+										}
+										position++
+										goto l57
+									l58:
+										position, tokenIndex, depth = position56, tokenIndex56, depth56
+										if buffer[position] != rune('e') {
+											goto l53
+										}
+										position++
+										if buffer[position] != rune('f') {
+											goto l53
+										}
+										position++
+										if buffer[position] != rune('f') {
+											goto l53
+										}
+										position++
+										if buffer[position] != rune('i') {
+											goto l53
+										}
+										position++
+										if buffer[position] != rune('c') {
+											goto l53
+										}
+										position++
+										if buffer[position] != rune('i') {
+											goto l53
+										}
+										position++
+										if buffer[position] != rune('e') {
+											goto l53
+										}
+										position++
+										if buffer[position] != rune('n') {
+											goto l53
+										}
+										position++
+										if buffer[position] != rune('c') {
+											goto l53
+										}
+										position++
+										if buffer[position] != rune('y') {
+											goto l53
+										}
+										position++
+									}
+								l57:
+									depth--
+									add(rulePegText, position55)
+								}
+								if !_rules[rulews]() {
+									goto l53
+								}
+								if buffer[position] != rune('(') {
+									goto l53
+								}
+								position++
+								{
+									add(ruleAction24, position)
+								}
+								if !_rules[rulestr]() {
+									goto l53
+								}
+								{
+									add(ruleAction25, position)
+								}
+								if buffer[position] != rune(',') {
+									goto l53
+								}
+								position++
+								if !_rules[rulestr]() {
+									goto l53
+								}
+								{
+									add(ruleAction26, position)
+								}
+								{
+									position59, tokenIndex59, depth59 := position, tokenIndex, depth
+									if buffer[position] != rune(',') {
+										goto l59
+									}
+									position++
+									if !_rules[rulews]() {
+										goto l59
+									}
+									if buffer[position] != rune('b') {
+										goto l59
+									}
+									position++
+									if buffer[position] != rune('a') {
+										goto l59
+									}
+									position++
+									if buffer[position] != rune('s') {
+										goto l59
+									}
+									position++
+									if buffer[position] != rune('e') {
+										goto l59
+									}
+									position++
+									if buffer[position] != rune('l') {
+										goto l59
+									}
+									position++
+									if buffer[position] != rune('i') {
+										goto l59
+									}
+									position++
+									if buffer[position] != rune('n') {
+										goto l59
+									}
+									position++
+									if buffer[position] != rune('e') {
+										goto l59
+									}
+									position++
+									if !_rules[rulews]() {
+										goto l59
+									}
+									if buffer[position] != rune('=') {
+										goto l59
+									}
+									position++
+									{
+										position61, tokenIndex61, depth61 := position, tokenIndex, depth
+										if !_rules[rulenumber]() {
+											goto l63
+										}
+										goto l62
+									l63:
+										position, tokenIndex, depth = position61, tokenIndex61, depth61
+										if !_rules[ruleparam]() {
+											goto l59
+										}
+									}
+								l62:
+									{
+										add(ruleAction27, position)
+									}
+									goto l60
+								l59:
+									position, tokenIndex, depth = position59, tokenIndex59, depth59
+								}
+							l60:
+								if buffer[position] != rune(')') {
+									goto l53
+								}
+								position++
+								if !_rules[rulews]() {
+									goto l53
+								}
+								{
+									position64 := position
+									depth++
+									if !_rules[ruleop]() {
+										goto l53
+									}
+									depth--
+									add(rulePegText, position64)
+								}
+								{
+									add(ruleAction28, position)
+								}
+								{
+									position65, tokenIndex65, depth65 := position, tokenIndex, depth
+									if !_rules[rulenumber]() {
+										goto l67
+									}
+									goto l66
+								l67:
+									position, tokenIndex, depth = position65, tokenIndex65, depth65
+									if !_rules[ruleparam]() {
+										goto l53
+									}
+								}
+							l66:
+								{
+									add(ruleAction29, position)
+								}
+								{
+									position68, tokenIndex68, depth68 := position, tokenIndex, depth
+									if !_rules[rulews]() {
+										goto l68
+									}
+									if buffer[position] != rune('*') {
+										goto l68
+									}
+									position++
+									if !_rules[rulestr]() {
+										goto l68
+									}
+									{
+										add(ruleAction30, position)
+									}
+									goto l69
+								l68:
+									position, tokenIndex, depth = position68, tokenIndex68, depth68
+								}
+							l69:
+								depth--
+								add(rulescaling, position54)
+							}
+							goto l29
+						l53:
+							position, tokenIndex, depth = position28, tokenIndex28, depth28
+							{
+								position71 := position
+								depth++
+								if !_rules[rulews]() {
+									goto l70
+								}
+								if buffer[position] != rune('p') {
+									goto l70
+								}
+								position++
+								if buffer[position] != rune('a') {
+									goto l70
+								}
+								position++
+								if buffer[position] != rune('r') {
+									goto l70
+								}
+								position++
+								if buffer[position] != rune('e') {
+									goto l70
+								}
+								position++
+								if buffer[position] != rune('t') {
+									goto l70
+								}
+								position++
+								if buffer[position] != rune('o') {
+									goto l70
+								}
+								position++
+								if !_rules[rulews]() {
+									goto l70
+								}
+								if buffer[position] != rune('(') {
+									goto l70
+								}
+								position++
+								if !_rules[ruleobjective]() {
+									goto l70
+								}
+							l72:
+								{
+									position73, tokenIndex73, depth73 := position, tokenIndex, depth
+									if buffer[position] != rune(',') {
+										goto l73
+									}
+									position++
+									if !_rules[ruleobjective]() {
+										goto l73
+									}
+									goto l72
+								l73:
+									position, tokenIndex, depth = position73, tokenIndex73, depth73
+								}
+								if buffer[position] != rune(')') {
+									goto l70
+								}
+								position++
+								if !_rules[rulews]() {
+									goto l70
+								}
+								if buffer[position] != rune('(') {
+									goto l70
+								}
+								position++
+								if !_rules[rulepredicates]() {
+									goto l70
+								}
+								if buffer[position] != rune(')') {
+									goto l70
+								}
+								position++
+								if !_rules[rulews]() {
+									goto l70
+								}
+								{
+									add(ruleAction31, position)
+								}
+								{
+									position74, tokenIndex74, depth74 := position, tokenIndex, depth
+									if buffer[position] != rune('n') {
+										goto l74
+									}
+									position++
+									if buffer[position] != rune('o') {
+										goto l74
+									}
+									position++
+									if buffer[position] != rune('t') {
+										goto l74
+									}
+									position++
+									if !_rules[rulews]() {
+										goto l74
+									}
+									if buffer[position] != rune('d') {
+										goto l74
+									}
+									position++
+									if buffer[position] != rune('o') {
+										goto l74
+									}
+									position++
+									if buffer[position] != rune('m') {
+										goto l74
+									}
+									position++
+									if buffer[position] != rune('i') {
+										goto l74
+									}
+									position++
+									if buffer[position] != rune('n') {
+										goto l74
+									}
+									position++
+									if buffer[position] != rune('a') {
+										goto l74
+									}
+									position++
+									if buffer[position] != rune('t') {
+										goto l74
+									}
+									position++
+									if buffer[position] != rune('e') {
+										goto l74
+									}
+									position++
+									if buffer[position] != rune('d') {
+										goto l74
+									}
+									position++
+									if !_rules[rulews]() {
+										goto l74
+									}
+									if buffer[position] != rune('b') {
+										goto l74
+									}
+									position++
+									if buffer[position] != rune('y') {
+										goto l74
+									}
+									position++
+									if !_rules[rulews]() {
+										goto l74
+									}
+									if buffer[position] != rune('(') {
+										goto l74
+									}
+									position++
+									if !_rules[rulepredicates]() {
+										goto l74
+									}
+									if buffer[position] != rune(')') {
+										goto l74
+									}
+									position++
+									if !_rules[rulews]() {
+										goto l74
+									}
+									{
+										add(ruleAction32, position)
+									}
+									goto l75
+								l74:
+									position, tokenIndex, depth = position74, tokenIndex74, depth74
+								}
+							l75:
+								depth--
+								add(rulepareto, position71)
+							}
+							goto l29
+						l70:
+							position, tokenIndex, depth = position28, tokenIndex28, depth28
+							{
+								position77 := position
+								depth++
+								if !_rules[rulevalue]() {
+									goto l76
+								}
+								{
+									add(ruleAction34, position)
+								}
+								if !_rules[rulews]() {
+									goto l76
+								}
+								if buffer[position] != rune('/') {
+									goto l76
+								}
+								position++
+								if !_rules[rulevalue]() {
+									goto l76
+								}
+								{
+									add(ruleAction35, position)
+								}
+								if !_rules[rulews]() {
+									goto l76
+								}
+								if buffer[position] != rune('b') {
+									goto l76
+								}
+								position++
+								if buffer[position] != rune('e') {
+									goto l76
+								}
+								position++
+								if buffer[position] != rune('t') {
+									goto l76
+								}
+								position++
+								if buffer[position] != rune('w') {
+									goto l76
+								}
+								position++
+								if buffer[position] != rune('e') {
+									goto l76
+								}
+								position++
+								if buffer[position] != rune('e') {
+									goto l76
+								}
+								position++
+								if buffer[position] != rune('n') {
+									goto l76
+								}
+								position++
+								{
+									position78, tokenIndex78, depth78 := position, tokenIndex, depth
+									if !_rules[rulenumber]() {
+										goto l80
+									}
+									goto l79
+								l80:
+									position, tokenIndex, depth = position78, tokenIndex78, depth78
+									if !_rules[ruleparam]() {
+										goto l76
+									}
+								}
+							l79:
+								{
+									add(ruleAction36, position)
+								}
+								if buffer[position] != rune('a') {
+									goto l76
+								}
+								position++
+								if buffer[position] != rune('n') {
+									goto l76
+								}
+								position++
+								if buffer[position] != rune('d') {
+									goto l76
+								}
+								position++
+								{
+									position81, tokenIndex81, depth81 := position, tokenIndex, depth
+									if !_rules[rulenumber]() {
+										goto l83
+									}
+									goto l82
+								l83:
+									position, tokenIndex, depth = position81, tokenIndex81, depth81
+									if !_rules[ruleparam]() {
+										goto l76
+									}
+								}
+							l82:
+								{
+									add(ruleAction37, position)
+								}
+								depth--
+								add(ruleratio, position77)
+							}
+							goto l29
+						l76:
+							position, tokenIndex, depth = position28, tokenIndex28, depth28
+							{
+								position85 := position
+								depth++
+								if !_rules[rulevalue]() {
+									goto l84
+								}
+								{
+									add(ruleAction38, position)
+								}
+								if !_rules[rulews]() {
+									goto l84
+								}
+								if buffer[position] != rune('w') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('i') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('t') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('h') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('i') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('n') {
+									goto l84
+								}
+								position++
+								{
+									position86, tokenIndex86, depth86 := position, tokenIndex, depth
+									if !_rules[rulenumber]() {
+										goto l88
+									}
+									goto l87
+								l88:
+									position, tokenIndex, depth = position86, tokenIndex86, depth86
+									if !_rules[ruleparam]() {
+										goto l84
+									}
+								}
+							l87:
+								{
+									add(ruleAction39, position)
+								}
+								if buffer[position] != rune('o') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('r') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('d') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('e') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('r') {
+									goto l84
+								}
+								position++
+								{
+									position89, tokenIndex89, depth89 := position, tokenIndex, depth
+									if buffer[position] != rune('s') {
+										goto l89
+									}
+									position++
+									goto l90
+								l89:
+									position, tokenIndex, depth = position89, tokenIndex89, depth89
+								}
+							l90:
+								if !_rules[rulews]() {
+									goto l84
+								}
+								if buffer[position] != rune('o') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('f') {
+									goto l84
+								}
+								position++
+								if !_rules[rulews]() {
+									goto l84
+								}
+								if buffer[position] != rune('m') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('a') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('g') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('n') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('i') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('t') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('u') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('d') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('e') {
+									goto l84
+								}
+								position++
+								if !_rules[rulews]() {
+									goto l84
+								}
+								if buffer[position] != rune('o') {
+									goto l84
+								}
+								position++
+								if buffer[position] != rune('f') {
+									goto l84
+								}
+								position++
+								if !_rules[rulevalue]() {
+									goto l84
+								}
+								{
+									add(ruleAction40, position)
+								}
+								depth--
+								add(rulemagnitude, position85)
+							}
+							goto l29
+						l84:
+							position, tokenIndex, depth = position28, tokenIndex28, depth28
+							{
+								position92 := position
+								depth++
+								if !_rules[rulevalue]() {
+									goto l91
+								}
+								{
+									add(ruleAction41, position)
+								}
+								{
+									position93, tokenIndex93, depth93 := position, tokenIndex, depth
+									if !_rules[rulews]() {
+										goto l95
+									}
+									if buffer[position] != rune('d') {
+										goto l95
+									}
+									position++
+									if buffer[position] != rune('o') {
+										goto l95
+									}
+									position++
+									if buffer[position] != rune('m') {
+										goto l95
+									}
+									position++
+									if buffer[position] != rune('i') {
+										goto l95
+									}
+									position++
+									if buffer[position] != rune('n') {
+										goto l95
+									}
+									position++
+									if buffer[position] != rune('a') {
+										goto l95
+									}
+									position++
+									if buffer[position] != rune('t') {
+										goto l95
+									}
+									position++
+									if buffer[position] != rune('e') {
+										goto l95
+									}
+									position++
+									if buffer[position] != rune('s') {
+										goto l95
+									}
+									position++
+									{
+										add(ruleAction42, position)
+									}
+									if !_rules[rulevalue]() {
+										goto l95
+									}
+									{
+										add(ruleAction43, position)
+									}
+									goto l94
+								l95:
+									position, tokenIndex, depth = position93, tokenIndex93, depth93
+									if !_rules[rulews]() {
+										goto l91
+									}
+									if buffer[position] != rune('s') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('a') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('m') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('e') {
+										goto l91
+									}
+									position++
+									if !_rules[rulews]() {
+										goto l91
+									}
+									if buffer[position] != rune('d') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('i') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('s') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('t') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('r') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('i') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('b') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('u') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('t') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('i') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('o') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('n') {
+										goto l91
+									}
+									position++
+									if !_rules[rulews]() {
+										goto l91
+									}
+									if buffer[position] != rune('a') {
+										goto l91
+									}
+									position++
+									if buffer[position] != rune('s') {
+										goto l91
+									}
+									position++
+									{
+										add(ruleAction44, position)
+									}
+									if !_rules[rulevalue]() {
+										goto l91
+									}
+									{
+										add(ruleAction45, position)
+									}
+									{
+										position96, tokenIndex96, depth96 := position, tokenIndex, depth
+										if !_rules[rulews]() {
+											goto l96
+										}
+										if buffer[position] != rune('a') {
+											goto l96
+										}
+										position++
+										if buffer[position] != rune('t') {
+											goto l96
+										}
+										position++
+										{
+											position98, tokenIndex98, depth98 := position, tokenIndex, depth
+											if !_rules[rulenumber]() {
+												goto l100
+											}
+											goto l99
+										l100:
+											position, tokenIndex, depth = position98, tokenIndex98, depth98
+											if !_rules[ruleparam]() {
+												goto l96
+											}
+										}
+									l99:
+										{
+											add(ruleAction46, position)
+										}
+										goto l97
+									l96:
+										position, tokenIndex, depth = position96, tokenIndex96, depth96
+									}
+								l97:
+								}
+							l94:
+								depth--
+								add(ruledistribution, position92)
+							}
+							goto l29
+						l91:
+							position, tokenIndex, depth = position28, tokenIndex28, depth28
+							{
+								position101 := position
+								depth++
+								if !_rules[rulevalue]() {
+									goto l27
+								}
+								{
+									add(ruleAction53, position)
+								}
+								{
+									position102 := position
+									depth++
+									if !_rules[ruleop]() {
+										goto l27
+									}
+									depth--
+									add(rulePegText, position102)
+								}
+								{
+									add(ruleAction54, position)
+								}
+								if !_rules[rulevalue]() {
+									goto l27
+								}
+								{
+									add(ruleAction55, position)
+								}
+								{
+									position103, tokenIndex103, depth103 := position, tokenIndex, depth
+									{
+										position105, tokenIndex105, depth105 := position, tokenIndex, depth
+										{
+											position108 := position
+											depth++
+											if !_rules[rulews]() {
+												goto l107
+											}
+											if buffer[position] != rune('*') {
+												goto l107
+											}
+											position++
+											{
+												position109, tokenIndex109, depth109 := position, tokenIndex, depth
+												if !_rules[rulenumber]() {
+													goto l111
+												}
+												goto l110
+											l111:
+												position, tokenIndex, depth = position109, tokenIndex109, depth109
+												if !_rules[ruleparam]() {
+													goto l107
+												}
+											}
+										l110:
+											{
+												add(ruleAction64, position)
+											}
+											depth--
+											add(rulerelative, position108)
+										}
+										goto l106
+									l107:
+										position, tokenIndex, depth = position105, tokenIndex105, depth105
+										{
+											position112 := position
+											depth++
+											if !_rules[rulews]() {
+												goto l103
+											}
+											if buffer[position] != rune('b') {
+												goto l103
+											}
+											position++
+											if buffer[position] != rune('y') {
+												goto l103
+											}
+											position++
+											{
+												position113, tokenIndex113, depth113 := position, tokenIndex, depth
+												if !_rules[rulenumber]() {
+													goto l115
+												}
+												goto l114
+											l115:
+												position, tokenIndex, depth = position113, tokenIndex113, depth113
+												if !_rules[ruleparam]() {
+													goto l103
+												}
+											}
+										l114:
+											{
+												add(ruleAction65, position)
+											}
+											if buffer[position] != rune('x') {
+												goto l103
+											}
+											position++
+											depth--
+											add(rulefactor, position112)
+										}
+									}
+								l106:
+									goto l104
+								l103:
+									position, tokenIndex, depth = position103, tokenIndex103, depth103
+								}
+							l104:
+								depth--
+								add(ruleresult, position101)
+							}
+						}
+					l29:
+						goto l26
+					l27:
+						position, tokenIndex, depth = position25, tokenIndex25, depth25
+						{
+							position116 := position
+							depth++
+							if !_rules[rulews]() {
+								goto l8
+							}
+							if buffer[position] != rune('r') {
+								goto l8
+							}
+							position++
+							if buffer[position] != rune('a') {
+								goto l8
+							}
+							position++
+							if buffer[position] != rune('n') {
+								goto l8
+							}
+							position++
+							if buffer[position] != rune('k') {
+								goto l8
+							}
+							position++
+							if !_rules[rulestr]() {
+								goto l8
+							}
+							{
+								add(ruleAction47, position)
+							}
+							if buffer[position] != rune('b') {
+								goto l8
+							}
+							position++
+							if buffer[position] != rune('y') {
+								goto l8
+							}
+							position++
+							if !_rules[rulestr]() {
+								goto l8
+							}
+							{
+								add(ruleAction48, position)
+							}
+							if buffer[position] != rune(':') {
+								goto l8
+							}
+							position++
+							if !_rules[rulerank_value]() {
+								goto l8
+							}
+							{
+								position117 := position
+								depth++
+								if !_rules[ruleop]() {
+									goto l8
+								}
+								depth--
+								add(rulePegText, position117)
+							}
+							{
+								add(ruleAction49, position)
+							}
+							if !_rules[rulerank_value]() {
+								goto l8
+							}
+						l118:
+							{
+								position119, tokenIndex119, depth119 := position, tokenIndex, depth
+								{
+									position120 := position
+									depth++
+									if !_rules[ruleop]() {
+										goto l119
+									}
+									depth--
+									add(rulePegText, position120)
+								}
+								{
+									add(ruleAction49, position)
+								}
+								if !_rules[rulerank_value]() {
+									goto l119
+								}
+								goto l118
+							l119:
+								position, tokenIndex, depth = position119, tokenIndex119, depth119
+							}
+							depth--
+							add(ruleranking, position116)
+						}
+					}
+				l26:
+					depth--
+					add(rulevalidation, position24)
+				}
+				{
+					position121, tokenIndex121, depth121 := position, tokenIndex, depth
+					{
+						position123 := position
+						depth++
+						{
+							position124, tokenIndex124, depth124 := position, tokenIndex, depth
+							if !_rules[rulews]() {
+								goto l126
+							}
+							if buffer[position] != rune('m') {
+								goto l126
+							}
+							position++
+							if buffer[position] != rune('a') {
+								goto l126
+							}
+							position++
+							if buffer[position] != rune('t') {
+								goto l126
+							}
+							position++
+							if buffer[position] != rune('c') {
+								goto l126
+							}
+							position++
+							if buffer[position] != rune('h') {
+								goto l126
+							}
+							position++
+							if buffer[position] != rune('i') {
+								goto l126
+							}
+							position++
+							if buffer[position] != rune('n') {
+								goto l126
+							}
+							position++
+							if buffer[position] != rune('g') {
+								goto l126
+							}
+							position++
+							if !_rules[rulestr]() {
+								goto l126
+							}
+							{
+								add(ruleAction8, position)
+							}
+							if buffer[position] != rune('w') {
+								goto l126
+							}
+							position++
+							if buffer[position] != rune('i') {
+								goto l126
+							}
+							position++
+							if buffer[position] != rune('t') {
+								goto l126
+							}
+							position++
+							if buffer[position] != rune('h') {
+								goto l126
+							}
+							position++
+							if buffer[position] != rune('i') {
+								goto l126
+							}
+							position++
+							if buffer[position] != rune('n') {
+								goto l126
+							}
+							position++
+							{
+								position127, tokenIndex127, depth127 := position, tokenIndex, depth
+								if !_rules[rulenumber]() {
+									goto l129
+								}
+								goto l128
+							l129:
+								position, tokenIndex, depth = position127, tokenIndex127, depth127
+								if !_rules[ruleparam]() {
+									goto l126
+								}
+							}
+						l128:
+							{
+								add(ruleAction9, position)
+							}
+							{
+								position130, tokenIndex130, depth130 := position, tokenIndex, depth
+								if buffer[position] != rune('%') {
+									goto l130
+								}
+								position++
+								{
+									add(ruleAction10, position)
+								}
+								goto l131
+							l130:
+								position, tokenIndex, depth = position130, tokenIndex130, depth130
+							}
+						l131:
+							goto l125
+						l126:
+							position, tokenIndex, depth = position124, tokenIndex124, depth124
+							if !_rules[rulews]() {
+								goto l121
+							}
+							if buffer[position] != rune('i') {
+								goto l121
+							}
+							position++
+							if buffer[position] != rune('n') {
+								goto l121
+							}
+							position++
+							if buffer[position] != rune('t') {
+								goto l121
+							}
+							position++
+							if buffer[position] != rune('e') {
+								goto l121
+							}
+							position++
+							if buffer[position] != rune('r') {
+								goto l121
+							}
+							position++
+							if buffer[position] != rune('p') {
+								goto l121
+							}
+							position++
+							if buffer[position] != rune('o') {
+								goto l121
+							}
+							position++
+							if buffer[position] != rune('l') {
+								goto l121
+							}
+							position++
+							if buffer[position] != rune('a') {
+								goto l121
+							}
+							position++
+							if buffer[position] != rune('t') {
+								goto l121
+							}
+							position++
+							if buffer[position] != rune('e') {
+								goto l121
+							}
+							position++
+							if !_rules[rulews]() {
+								goto l121
+							}
+							if buffer[position] != rune('o') {
+								goto l121
+							}
+							position++
+							if buffer[position] != rune('n') {
+								goto l121
+							}
+							position++
+							if !_rules[rulestr]() {
+								goto l121
+							}
+							{
+								add(ruleAction11, position)
+							}
+						}
+					l125:
+						depth--
+						add(rulejoin, position123)
+					}
+					goto l122
+				l121:
+					position, tokenIndex, depth = position121, tokenIndex121, depth121
+				}
+			l122:
+				{
+					position132, tokenIndex132, depth132 := position, tokenIndex, depth
+					{
+						position134 := position
+						depth++
+						if !_rules[rulews]() {
+							goto l132
+						}
+						if buffer[position] != rune('e') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('x') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('c') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('l') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('u') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('d') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('i') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('n') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('g') {
+							goto l132
+						}
+						position++
+						if !_rules[rulews]() {
+							goto l132
+						}
+						if buffer[position] != rune('o') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('u') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('l') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('i') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('e') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('r') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('s') {
+							goto l132
+						}
+						position++
+						if !_rules[rulews]() {
+							goto l132
+						}
+						if buffer[position] != rune('b') {
+							goto l132
+						}
+						position++
+						if buffer[position] != rune('y') {
+							goto l132
+						}
+						position++
+						if !_rules[rulews]() {
+							goto l132
+						}
+						{
+							position135 := position
+							depth++
+							{
+								position136, tokenIndex136, depth136 := position, tokenIndex, depth
+								if buffer[position] != rune('i') {
+									goto l138
+								}
+								position++
+								if buffer[position] != rune('q') {
+									goto l138
+								}
+								position++
+								if buffer[position] != rune('r') {
+									goto l138
+								}
+								position++
+								goto l137
+							l138:
+								position, tokenIndex, depth = position136, tokenIndex136, depth136
+								if buffer[position] != rune('z') {
+									goto l139
+								}
+								position++
+								if buffer[position] != rune('s') {
+									goto l139
+								}
+								position++
+								if buffer[position] != rune('c') {
+									goto l139
+								}
+								position++
+								if buffer[position] != rune('o') {
+									goto l139
+								}
+								position++
+								if buffer[position] != rune('r') {
+									goto l139
+								}
+								position++
+								if buffer[position] != rune('e') {
+									goto l139
+								}
+								position++
+								goto l137
+							l139:
+								position, tokenIndex, depth = position136, tokenIndex136, depth136
+								if buffer[position] != rune('m') {
+									goto l132
+								}
+								position++
+								if buffer[position] != rune('a') {
+									goto l132
+								}
+								position++
+								if buffer[position] != rune('d') {
+									goto l132
+								}
+								position++
+							}
+						l137:
+							depth--
+							add(rulePegText, position135)
+						}
+						if !_rules[rulews]() {
+							goto l132
+						}
+						{
+							add(ruleAction12, position)
+						}
+						{
+							position140, tokenIndex140, depth140 := position, tokenIndex, depth
+							if !_rules[rulenumber]() {
+								goto l142
+							}
+							goto l141
+						l142:
+							position, tokenIndex, depth = position140, tokenIndex140, depth140
+							if !_rules[ruleparam]() {
+								goto l132
+							}
+						}
+					l141:
+						{
+							add(ruleAction13, position)
+						}
+						depth--
+						add(ruleoutliers, position134)
+					}
+					goto l133
+				l132:
+					position, tokenIndex, depth = position132, tokenIndex132, depth132
+				}
+			l133:
+				{
+					position143, tokenIndex143, depth143 := position, tokenIndex, depth
+					{
+						position145 := position
+						depth++
+						if !_rules[rulews]() {
+							goto l143
+						}
+						if buffer[position] != rune('o') {
+							goto l143
+						}
+						position++
+						if buffer[position] != rune('n') {
+							goto l143
+						}
+						position++
+						if !_rules[rulews]() {
+							goto l143
+						}
+						if buffer[position] != rune('m') {
+							goto l143
+						}
+						position++
+						if buffer[position] != rune('i') {
+							goto l143
+						}
+						position++
+						if buffer[position] != rune('s') {
+							goto l143
+						}
+						position++
+						if buffer[position] != rune('s') {
+							goto l143
+						}
+						position++
+						if buffer[position] != rune('i') {
+							goto l143
+						}
+						position++
+						if buffer[position] != rune('n') {
+							goto l143
+						}
+						position++
+						if buffer[position] != rune('g') {
+							goto l143
+						}
+						position++
+						if !_rules[rulews]() {
+							goto l143
+						}
+						{
+							position146 := position
+							depth++
+							{
+								position147, tokenIndex147, depth147 := position, tokenIndex, depth
+								if buffer[position] != rune('f') {
+									goto l149
+								}
+								position++
+								if buffer[position] != rune('a') {
+									goto l149
+								}
+								position++
+								if buffer[position] != rune('i') {
+									goto l149
+								}
+								position++
+								if buffer[position] != rune('l') {
+									goto l149
+								}
+								position++
+								goto l148
+							l149:
+								position, tokenIndex, depth = position147, tokenIndex147, depth147
+								if buffer[position] != rune('s') {
+									goto l150
+								}
+								position++
+								if buffer[position] != rune('k') {
+									goto l150
+								}
+								position++
+								if buffer[position] != rune('i') {
+									goto l150
+								}
+								position++
+								if buffer[position] != rune('p') {
+									goto l150
+								}
+								position++
+								goto l148
+							l150:
+								position, tokenIndex, depth = position147, tokenIndex147, depth147
+								if buffer[position] != rune('e') {
+									goto l143
+								}
+								position++
+								if buffer[position] != rune('r') {
+									goto l143
+								}
+								position++
+								if buffer[position] != rune('r') {
+									goto l143
+								}
+								position++
+								if buffer[position] != rune('o') {
+									goto l143
+								}
+								position++
+								if buffer[position] != rune('r') {
+									goto l143
+								}
+								position++
+							}
+						l148:
+							depth--
+							add(rulePegText, position146)
+						}
+						{
+							position151, tokenIndex151, depth151 := position, tokenIndex, depth
+							{
+								switch buffer[position] {
+								case 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z':
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l151
+									}
+									position++
+									break
+								case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+									if c := buffer[position]; c < rune('A') || c > rune('Z') {
+										goto l151
+									}
+									position++
+									break
+								case '_':
+									if buffer[position] != rune('_') {
+										goto l151
+									}
+									position++
+									break
+								default:
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l151
+									}
+									position++
+									break
+								}
+							}
+							goto l143
+						l151:
+							position, tokenIndex, depth = position151, tokenIndex151, depth151
+						}
+						{
+							add(ruleAction14, position)
+						}
+						depth--
+						add(rulemissing, position145)
+					}
+					goto l144
+				l143:
+					position, tokenIndex, depth = position143, tokenIndex143, depth143
+				}
+			l144:
+				{
+					add(ruleAction0, position)
+				}
+				depth--
+				add(rulestatement, position9)
+			}
+			return true
+		l8:
+			position, tokenIndex, depth = position8, tokenIndex8, depth8
+			return false
+		},
+		/* 3 claim <- <(ws ('c' 'l' 'a' 'i' 'm') ws quoted Action1 ws ':' (ws quoted Action2)?)> */
+		nil,
+		/* 4 global_predicates <- <(ws ('f' 'o' 'r') predicates Action3)> */
+		func() bool {
+			position152, tokenIndex152, depth152 := position, tokenIndex, depth
+			{
+				position153 := position
+				depth++
+				if !_rules[rulews]() {
+					goto l152
+				}
+				if buffer[position] != rune('f') {
+					goto l152
+				}
+				position++
+				if buffer[position] != rune('o') {
+					goto l152
+				}
+				position++
+				if buffer[position] != rune('r') {
+					goto l152
+				}
+				position++
+				if !_rules[rulepredicates]() {
+					goto l152
+				}
+				{
+					add(ruleAction3, position)
+				}
+				depth--
+				add(ruleglobal_predicates, position153)
+			}
+			return true
+		l152:
+			position, tokenIndex, depth = position152, tokenIndex152, depth152
+			return false
+		},
+		/* 5 grouping <- <(ws ('f' 'o' 'r') ws ('e' 'a' 'c' 'h') !([a-z] / [A-Z] / '_' / [0-9]) str Action4 (',' str Action5)*)> */
+		func() bool {
+			position154, tokenIndex154, depth154 := position, tokenIndex, depth
+			{
+				position155 := position
+				depth++
+				if !_rules[rulews]() {
+					goto l154
+				}
+				if buffer[position] != rune('f') {
+					goto l154
+				}
+				position++
+				if buffer[position] != rune('o') {
+					goto l154
+				}
+				position++
+				if buffer[position] != rune('r') {
+					goto l154
+				}
+				position++
+				if !_rules[rulews]() {
+					goto l154
+				}
+				if buffer[position] != rune('e') {
+					goto l154
+				}
+				position++
+				if buffer[position] != rune('a') {
+					goto l154
+				}
+				position++
+				if buffer[position] != rune('c') {
+					goto l154
+				}
+				position++
+				if buffer[position] != rune('h') {
+					goto l154
+				}
+				position++
+				{
+					position156, tokenIndex156, depth156 := position, tokenIndex, depth
+					{
+						switch buffer[position] {
+						case 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z':
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l156
+							}
+							position++
+							break
+						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l156
+							}
+							position++
+							break
+						case '_':
 							if buffer[position] != rune('_') {
-								goto l46
+								goto l156
+							}
+							position++
+							break
+						default:
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l156
+							}
+							position++
+							break
+						}
+					}
+					goto l154
+				l156:
+					position, tokenIndex, depth = position156, tokenIndex156, depth156
+				}
+				if !_rules[rulestr]() {
+					goto l154
+				}
+				{
+					add(ruleAction4, position)
+				}
+			l157:
+				{
+					position158, tokenIndex158, depth158 := position, tokenIndex, depth
+					if buffer[position] != rune(',') {
+						goto l158
+					}
+					position++
+					if !_rules[rulestr]() {
+						goto l158
+					}
+					{
+						add(ruleAction5, position)
+					}
+					goto l157
+				l158:
+					position, tokenIndex, depth = position158, tokenIndex158, depth158
+				}
+				depth--
+				add(rulegrouping, position155)
+			}
+			return true
+		l154:
+			position, tokenIndex, depth = position154, tokenIndex154, depth154
+			return false
+		},
+		/* 6 predicates <- <(Action6 predicate (('a' 'n' 'd') predicate)* Action7)> */
+		func() bool {
+			position159, tokenIndex159, depth159 := position, tokenIndex, depth
+			{
+				position160 := position
+				depth++
+				{
+					add(ruleAction6, position)
+				}
+				if !_rules[rulepredicate]() {
+					goto l159
+				}
+			l161:
+				{
+					position162, tokenIndex162, depth162 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l162
+					}
+					position++
+					if buffer[position] != rune('n') {
+						goto l162
+					}
+					position++
+					if buffer[position] != rune('d') {
+						goto l162
+					}
+					position++
+					if !_rules[rulepredicate]() {
+						goto l162
+					}
+					goto l161
+				l162:
+					position, tokenIndex, depth = position162, tokenIndex162, depth162
+				}
+				{
+					add(ruleAction7, position)
+				}
+				depth--
+				add(rulepredicates, position160)
+			}
+			return true
+		l159:
+			position, tokenIndex, depth = position159, tokenIndex159, depth159
+			return false
+		},
+		/* 7 join <- <((ws ('m' 'a' 't' 'c' 'h' 'i' 'n' 'g') str Action8 ('w' 'i' 't' 'h' 'i' 'n') (number / param) Action9 ('%' Action10)?) / (ws ('i' 'n' 't' 'e' 'r' 'p' 'o' 'l' 'a' 't' 'e') ws ('o' 'n') str Action11))> */
+		nil,
+		/* 8 outliers <- <(ws ('e' 'x' 'c' 'l' 'u' 'd' 'i' 'n' 'g') ws ('o' 'u' 't' 'l' 'i' 'e' 'r' 's') ws ('b' 'y') ws <(('i' 'q' 'r') / ('z' 's' 'c' 'o' 'r' 'e') / ('m' 'a' 'd'))> ws Action12 (number / param) Action13)> */
+		nil,
+		/* 9 missing <- <(ws ('o' 'n') ws ('m' 'i' 's' 's' 'i' 'n' 'g') ws <(('f' 'a' 'i' 'l') / ('s' 'k' 'i' 'p') / ('e' 'r' 'r' 'o' 'r'))> !([a-z] / [A-Z] / '_' / [0-9]) Action14)> */
+		nil,
+		/* 10 validation <- <((ws ('e' 'x' 'p' 'e' 'c' 't') (variability / correlation / scaling / pareto / ratio / magnitude / distribution / result)) / ranking)> */
+		nil,
+		/* 11 variability <- <(ws <(('s' 't' 'd' 'd' 'e' 'v') / ('c' 'v') / ('i' 'q' 'r') / ('m' 'a' 'd'))> ws '(' Action15 value ')' ws Action16 <op> Action17 (number / param) Action18)> */
+		nil,
+		/* 12 correlation <- <(ws <(('c' 'o' 'r' 'r') / ('s' 'p' 'e' 'a' 'r' 'm' 'a' 'n') / ('k' 'e' 'n' 'd' 'a' 'l' 'l'))> ws '(' Action19 str Action20 ',' str Action21 ')' ws <op> Action22 (number / param) Action23)> */
+		nil,
+		/* 13 scaling <- <(ws <(('s' 'p' 'e' 'e' 'd' 'u' 'p') / ('e' 'f' 'f' 'i' 'c' 'i' 'e' 'n' 'c' 'y'))> ws '(' Action24 str Action25 ',' str Action26 (',' ws ('b' 'a' 's' 'e' 'l' 'i' 'n' 'e') ws '=' (number / param) Action27)? ')' ws <op> Action28 (number / param) Action29 (ws '*' str Action30)?)> */
+		nil,
+		/* 14 pareto <- <(ws ('p' 'a' 'r' 'e' 't' 'o') ws '(' objective (',' objective)* ')' ws '(' predicates ')' ws Action31 (('n' 'o' 't') ws ('d' 'o' 'm' 'i' 'n' 'a' 't' 'e' 'd') ws ('b' 'y') ws '(' predicates ')' ws Action32)?)> */
+		nil,
+		/* 15 objective <- <(str <(('m' 'a' 'x') / ('m' 'i' 'n'))> ws Action33)> */
+		func() bool {
+			position163, tokenIndex163, depth163 := position, tokenIndex, depth
+			{
+				position164 := position
+				depth++
+				if !_rules[rulestr]() {
+					goto l163
+				}
+				{
+					position165 := position
+					depth++
+					{
+						position166, tokenIndex166, depth166 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l168
+						}
+						position++
+						if buffer[position] != rune('a') {
+							goto l168
+						}
+						position++
+						if buffer[position] != rune('x') {
+							goto l168
+						}
+						position++
+						goto l167
+					l168:
+						position, tokenIndex, depth = position166, tokenIndex166, depth166
+						if buffer[position] != rune('m') {
+							goto l163
+						}
+						position++
+						if buffer[position] != rune('i') {
+							goto l163
+						}
+						position++
+						if buffer[position] != rune('n') {
+							goto l163
+						}
+						position++
+					}
+				l167:
+					depth--
+					add(rulePegText, position165)
+				}
+				if !_rules[rulews]() {
+					goto l163
+				}
+				{
+					add(ruleAction33, position)
+				}
+				depth--
+				add(ruleobjective, position164)
+			}
+			return true
+		l163:
+			position, tokenIndex, depth = position163, tokenIndex163, depth163
+			return false
+		},
+		/* 16 ratio <- <(value Action34 ws '/' value Action35 ws ('b' 'e' 't' 'w' 'e' 'e' 'n') (number / param) Action36 ('a' 'n' 'd') (number / param) Action37)> */
+		nil,
+		/* 17 magnitude <- <(value Action38 ws ('w' 'i' 't' 'h' 'i' 'n') (number / param) Action39 ('o' 'r' 'd' 'e' 'r') 's'? ws ('o' 'f') ws ('m' 'a' 'g' 'n' 'i' 't' 'u' 'd' 'e') ws ('o' 'f') value Action40)> */
+		nil,
+		/* 18 distribution <- <(value Action41 ((ws ('d' 'o' 'm' 'i' 'n' 'a' 't' 'e' 's') Action42 value Action43) / (ws ('s' 'a' 'm' 'e') ws ('d' 'i' 's' 't' 'r' 'i' 'b' 'u' 't' 'i' 'o' 'n') ws ('a' 's') Action44 value Action45 (ws ('a' 't') (number / param) Action46)?)))> */
+		nil,
+		/* 19 ranking <- <(ws ('r' 'a' 'n' 'k') str Action47 ('b' 'y') str Action48 ':' rank_value (<op> Action49 rank_value)+)> */
+		nil,
+		/* 20 rank_value <- <(ws (('\'' str '\'' Action50) / (number !([a-z] / [A-Z] / '_') Action51) / (str Action52)) ws)> */
+		func() bool {
+			position169, tokenIndex169, depth169 := position, tokenIndex, depth
+			{
+				position170 := position
+				depth++
+				if !_rules[rulews]() {
+					goto l169
+				}
+				{
+					position171, tokenIndex171, depth171 := position, tokenIndex, depth
+					if buffer[position] != rune('\'') {
+						goto l173
+					}
+					position++
+					if !_rules[rulestr]() {
+						goto l173
+					}
+					if buffer[position] != rune('\'') {
+						goto l173
+					}
+					position++
+					{
+						add(ruleAction50, position)
+					}
+					goto l172
+				l173:
+					position, tokenIndex, depth = position171, tokenIndex171, depth171
+					if !_rules[rulenumber]() {
+						goto l174
+					}
+					{
+						position175, tokenIndex175, depth175 := position, tokenIndex, depth
+						{
+							switch buffer[position] {
+							case 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z':
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l175
+								}
+								position++
+								break
+							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l175
+								}
+								position++
+								break
+							default:
+								if buffer[position] != rune('_') {
+									goto l175
+								}
+								position++
+								break
+							}
+						}
+						goto l174
+					l175:
+						position, tokenIndex, depth = position175, tokenIndex175, depth175
+					}
+					{
+						add(ruleAction51, position)
+					}
+					goto l172
+				l174:
+					position, tokenIndex, depth = position171, tokenIndex171, depth171
+					if !_rules[rulestr]() {
+						goto l169
+					}
+					{
+						add(ruleAction52, position)
+					}
+				}
+			l172:
+				if !_rules[rulews]() {
+					goto l169
+				}
+				depth--
+				add(rulerank_value, position170)
+			}
+			return true
+		l169:
+			position, tokenIndex, depth = position169, tokenIndex169, depth169
+			return false
+		},
+		/* 21 result <- <(value Action53 <op> Action54 value Action55 (relative / factor)?)> */
+		nil,
+		/* 22 value <- <((str / param) ws Action56 ('(' predicates ')' ws)? Action57)> */
+		func() bool {
+			position176, tokenIndex176, depth176 := position, tokenIndex, depth
+			{
+				position177 := position
+				depth++
+				{
+					position178, tokenIndex178, depth178 := position, tokenIndex, depth
+					if !_rules[rulestr]() {
+						goto l180
+					}
+					goto l179
+				l180:
+					position, tokenIndex, depth = position178, tokenIndex178, depth178
+					if !_rules[ruleparam]() {
+						goto l176
+					}
+				}
+			l179:
+				if !_rules[rulews]() {
+					goto l176
+				}
+				{
+					add(ruleAction56, position)
+				}
+				{
+					position181, tokenIndex181, depth181 := position, tokenIndex, depth
+					if buffer[position] != rune('(') {
+						goto l181
+					}
+					position++
+					if !_rules[rulepredicates]() {
+						goto l181
+					}
+					if buffer[position] != rune(')') {
+						goto l181
+					}
+					position++
+					if !_rules[rulews]() {
+						goto l181
+					}
+					goto l182
+				l181:
+					position, tokenIndex, depth = position181, tokenIndex181, depth181
+				}
+			l182:
+				{
+					add(ruleAction57, position)
+				}
+				depth--
+				add(rulevalue, position177)
+			}
+			return true
+		l176:
+			position, tokenIndex, depth = position176, tokenIndex176, depth176
+			return false
+		},
+		/* 23 op <- <(ws (('>' '=') / ('<' '=') / ('<' '>') / '=' / '>' / '<'))> */
+		func() bool {
+			position183, tokenIndex183, depth183 := position, tokenIndex, depth
+			{
+				position184 := position
+				depth++
+				if !_rules[rulews]() {
+					goto l183
+				}
+				{
+					position185, tokenIndex185, depth185 := position, tokenIndex, depth
+					if buffer[position] != rune('>') {
+						goto l187
+					}
+					position++
+					if buffer[position] != rune('=') {
+						goto l187
+					}
+					position++
+					goto l186
+				l187:
+					position, tokenIndex, depth = position185, tokenIndex185, depth185
+					if buffer[position] != rune('<') {
+						goto l188
+					}
+					position++
+					if buffer[position] != rune('=') {
+						goto l188
+					}
+					position++
+					goto l186
+				l188:
+					position, tokenIndex, depth = position185, tokenIndex185, depth185
+					if buffer[position] != rune('<') {
+						goto l189
+					}
+					position++
+					if buffer[position] != rune('>') {
+						goto l189
+					}
+					position++
+					goto l186
+				l189:
+					position, tokenIndex, depth = position185, tokenIndex185, depth185
+					if buffer[position] != rune('=') {
+						goto l190
+					}
+					position++
+					goto l186
+				l190:
+					position, tokenIndex, depth = position185, tokenIndex185, depth185
+					if buffer[position] != rune('>') {
+						goto l191
+					}
+					position++
+					goto l186
+				l191:
+					position, tokenIndex, depth = position185, tokenIndex185, depth185
+					if buffer[position] != rune('<') {
+						goto l183
+					}
+					position++
+				}
+			l186:
+				depth--
+				add(ruleop, position184)
+			}
+			return true
+		l183:
+			position, tokenIndex, depth = position183, tokenIndex183, depth183
+			return false
+		},
+		/* 24 predicate <- <(str Action58 <op> Action59 literal)> */
+		func() bool {
+			position192, tokenIndex192, depth192 := position, tokenIndex, depth
+			{
+				position193 := position
+				depth++
+				if !_rules[rulestr]() {
+					goto l192
+				}
+				{
+					add(ruleAction58, position)
+				}
+				{
+					position194 := position
+					depth++
+					if !_rules[ruleop]() {
+						goto l192
+					}
+					depth--
+					add(rulePegText, position194)
+				}
+				{
+					add(ruleAction59, position)
+				}
+				{
+					position195 := position
+					depth++
+					if !_rules[rulews]() {
+						goto l192
+					}
+					{
+						position196, tokenIndex196, depth196 := position, tokenIndex, depth
+						if !_rules[rulenumber]() {
+							goto l198
+						}
+						{
+							add(ruleAction60, position)
+						}
+						goto l197
+					l198:
+						position, tokenIndex, depth = position196, tokenIndex196, depth196
+						if buffer[position] != rune('\'') {
+							goto l199
+						}
+						position++
+						if !_rules[rulestr]() {
+							goto l199
+						}
+						if buffer[position] != rune('\'') {
+							goto l199
+						}
+						position++
+						{
+							add(ruleAction61, position)
+						}
+						goto l197
+					l199:
+						position, tokenIndex, depth = position196, tokenIndex196, depth196
+						if !_rules[ruleparam]() {
+							goto l200
+						}
+						{
+							add(ruleAction62, position)
+						}
+						goto l197
+					l200:
+						position, tokenIndex, depth = position196, tokenIndex196, depth196
+						if buffer[position] != rune('*') {
+							goto l192
+						}
+						position++
+						if buffer[position] != rune('o') {
+							goto l192
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l192
+						}
+						position++
+						if buffer[position] != rune('h') {
+							goto l192
+						}
+						position++
+						if buffer[position] != rune('e') {
+							goto l192
+						}
+						position++
+						if buffer[position] != rune('r') {
+							goto l192
+						}
+						position++
+						if buffer[position] != rune('*') {
+							goto l192
+						}
+						position++
+						{
+							add(ruleAction63, position)
+						}
+					}
+				l197:
+					if !_rules[rulews]() {
+						goto l192
+					}
+					depth--
+					add(ruleliteral, position195)
+				}
+				depth--
+				add(rulepredicate, position193)
+			}
+			return true
+		l192:
+			position, tokenIndex, depth = position192, tokenIndex192, depth192
+			return false
+		},
+		/* 25 literal <- <(ws ((number Action60) / ('\'' str '\'' Action61) / (param Action62) / (('*' 'o' 't' 'h' 'e' 'r' '*') Action63)) ws)> */
+		nil,
+		/* 26 relative <- <(ws '*' (number / param) Action64)> */
+		nil,
+		/* 27 factor <- <(ws ('b' 'y') (number / param) Action65 'x')> */
+		nil,
+		/* 28 str <- <(ws <(([a-z] / [A-Z] / '_' / [0-9]) ([a-z] / [A-Z] / '_' / [0-9])*)> ws Action66)> */
+		func() bool {
+			position201, tokenIndex201, depth201 := position, tokenIndex, depth
+			{
+				position202 := position
+				depth++
+				if !_rules[rulews]() {
+					goto l201
+				}
+				{
+					position203 := position
+					depth++
+					{
+						switch buffer[position] {
+						case 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z':
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l201
 							}
 							position++
 							break
 						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l46
+								goto l201
+							}
+							position++
+							break
+						case '_':
+							if buffer[position] != rune('_') {
+								goto l201
 							}
 							position++
 							break
 						default:
-							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l46
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l201
 							}
 							position++
 							break
 						}
 					}
-
-				l50:
+				l204:
 					{
-						position51, tokenIndex51, depth51 := position, tokenIndex, depth
+						position205, tokenIndex205, depth205 := position, tokenIndex, depth
 						{
 							switch buffer[position] {
-							case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l51
+							case 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z':
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l205
 								}
 								position++
 								break
-							case '_':
-								if buffer[position] != rune('_') {
-									goto l51
+							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l205
 								}
 								position++
 								break
-							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
-								if c := buffer[position]; c < rune('A') || c > rune('Z') {
-									goto l51
+							case '_':
+								if buffer[position] != rune('_') {
+									goto l205
 								}
 								position++
 								break
 							default:
-								if c := buffer[position]; c < rune('a') || c > rune('z') {
-									goto l51
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l205
 								}
 								position++
 								break
 							}
 						}
-
-						goto l50
-					l51:
-						position, tokenIndex, depth = position51, tokenIndex51, depth51
+						goto l204
+					l205:
+						position, tokenIndex, depth = position205, tokenIndex205, depth205
 					}
 					depth--
-					add(rulePegText, position48)
+					add(rulePegText, position203)
 				}
 				if !_rules[rulews]() {
-					goto l46
+					goto l201
 				}
 				{
-					add(ruleAction8, position)
+					add(ruleAction66, position)
 				}
 				depth--
-				add(rulestr, position47)
+				add(rulestr, position202)
 			}
 			return true
-		l46:
-			position, tokenIndex, depth = position46, tokenIndex46, depth46
+		l201:
+			position, tokenIndex, depth = position201, tokenIndex201, depth201
 			return false
 		},
-		/* 11 number <- <(ws <([0-9]+ ('.' [0-9]+)?)> ws Action9)> */
+		/* 29 number <- <(ws <('-'? [0-9]+ ('.' [0-9]+)?)> ws Action67)> */
 		func() bool {
-			position54, tokenIndex54, depth54 := position, tokenIndex, depth
+			position206, tokenIndex206, depth206 := position, tokenIndex, depth
 			{
-				position55 := position
+				position207 := position
 				depth++
 				if !_rules[rulews]() {
-					goto l54
+					goto l206
 				}
 				{
-					position56 := position
+					position208 := position
 					depth++
+					{
+						position209, tokenIndex209, depth209 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l209
+						}
+						position++
+						goto l210
+					l209:
+						position, tokenIndex, depth = position209, tokenIndex209, depth209
+					}
+				l210:
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l54
+						goto l206
 					}
 					position++
-				l57:
+				l211:
 					{
-						position58, tokenIndex58, depth58 := position, tokenIndex, depth
+						position212, tokenIndex212, depth212 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l212
 						}
 						position++
-						goto l57
-					l58:
-						position, tokenIndex, depth = position58, tokenIndex58, depth58
+						goto l211
+					l212:
+						position, tokenIndex, depth = position212, tokenIndex212, depth212
 					}
 					{
-						position59, tokenIndex59, depth59 := position, tokenIndex, depth
+						position213, tokenIndex213, depth213 := position, tokenIndex, depth
 						if buffer[position] != rune('.') {
-							goto l59
+							goto l213
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l59
+							goto l213
 						}
 						position++
-					l61:
+					l215:
 						{
-							position62, tokenIndex62, depth62 := position, tokenIndex, depth
+							position216, tokenIndex216, depth216 := position, tokenIndex, depth
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l62
+								goto l216
 							}
 							position++
-							goto l61
-						l62:
-							position, tokenIndex, depth = position62, tokenIndex62, depth62
+							goto l215
+						l216:
+							position, tokenIndex, depth = position216, tokenIndex216, depth216
 						}
-						goto l60
-					l59:
-						position, tokenIndex, depth = position59, tokenIndex59, depth59
+						goto l214
+					l213:
+						position, tokenIndex, depth = position213, tokenIndex213, depth213
 					}
-				l60:
+				l214:
 					depth--
-					add(rulePegText, position56)
+					add(rulePegText, position208)
 				}
 				if !_rules[rulews]() {
-					goto l54
+					goto l206
 				}
 				{
-					add(ruleAction9, position)
+					add(ruleAction67, position)
 				}
 				depth--
-				add(rulenumber, position55)
+				add(rulenumber, position207)
 			}
 			return true
-		l54:
-			position, tokenIndex, depth = position54, tokenIndex54, depth54
+		l206:
+			position, tokenIndex, depth = position206, tokenIndex206, depth206
 			return false
 		},
-		/* 12 ws <- <((&('\r') '\r') | (&('\n') '\n') | (&('\t') '\t') | (&(' ') ' '))*> */
+		/* 30 param <- <(ws <(('$' / ':') ([a-z] / [A-Z] / '_') ([a-z] / [A-Z] / '_' / [0-9])*)> ws Action68)> */
 		func() bool {
+			position217, tokenIndex217, depth217 := position, tokenIndex, depth
 			{
-				position65 := position
+				position218 := position
 				depth++
-			l66:
+				if !_rules[rulews]() {
+					goto l217
+				}
 				{
-					position67, tokenIndex67, depth67 := position, tokenIndex, depth
+					position219 := position
+					depth++
 					{
 						switch buffer[position] {
-						case '\r':
-							if buffer[position] != rune('\r') {
-								goto l67
+						case '$':
+							if buffer[position] != rune('$') {
+								goto l217
+							}
+							position++
+							break
+						default:
+							if buffer[position] != rune(':') {
+								goto l217
 							}
 							position++
 							break
-						case '\n':
-							if buffer[position] != rune('\n') {
-								goto l67
+						}
+					}
+					{
+						switch buffer[position] {
+						case 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z':
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l217
 							}
 							position++
 							break
-						case '\t':
-							if buffer[position] != rune('\t') {
-								goto l67
+						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l217
 							}
 							position++
 							break
 						default:
-							if buffer[position] != rune(' ') {
-								goto l67
+							if buffer[position] != rune('_') {
+								goto l217
 							}
 							position++
 							break
 						}
 					}
-
-					goto l66
-				l67:
-					position, tokenIndex, depth = position67, tokenIndex67, depth67
+				l220:
+					{
+						position221, tokenIndex221, depth221 := position, tokenIndex, depth
+						{
+							switch buffer[position] {
+							case 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z':
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l221
+								}
+								position++
+								break
+							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l221
+								}
+								position++
+								break
+							case '_':
+								if buffer[position] != rune('_') {
+									goto l221
+								}
+								position++
+								break
+							default:
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l221
+								}
+								position++
+								break
+							}
+						}
+						goto l220
+					l221:
+						position, tokenIndex, depth = position221, tokenIndex221, depth221
+					}
+					depth--
+					add(rulePegText, position219)
+				}
+				if !_rules[rulews]() {
+					goto l217
+				}
+				{
+					add(ruleAction68, position)
+				}
+				depth--
+				add(ruleparam, position218)
+			}
+			return true
+		l217:
+			position, tokenIndex, depth = position217, tokenIndex217, depth217
+			return false
+		},
+		/* 31 quoted <- <('"' <(!'"' .)*> '"')> */
+		func() bool {
+			position222, tokenIndex222, depth222 := position, tokenIndex, depth
+			{
+				position223 := position
+				depth++
+				if buffer[position] != rune('"') {
+					goto l222
+				}
+				position++
+				{
+					position224 := position
+					depth++
+				l225:
+					{
+						position226, tokenIndex226, depth226 := position, tokenIndex, depth
+						{
+							position227, tokenIndex227, depth227 := position, tokenIndex, depth
+							if buffer[position] != rune('"') {
+								goto l227
+							}
+							position++
+							goto l226
+						l227:
+							position, tokenIndex, depth = position227, tokenIndex227, depth227
+						}
+						if !matchDot() {
+							goto l226
+						}
+						goto l225
+					l226:
+						position, tokenIndex, depth = position226, tokenIndex226, depth226
+					}
+					depth--
+					add(rulePegText, position224)
+				}
+				if buffer[position] != rune('"') {
+					goto l222
+				}
+				position++
+				depth--
+				add(rulequoted, position223)
+			}
+			return true
+		l222:
+			position, tokenIndex, depth = position222, tokenIndex222, depth222
+			return false
+		},
+		/* 32 ws <- <((' ' / '\t' / '\n' / '\r') / comment)*> */
+		func() bool {
+			{
+				position229 := position
+				depth++
+			l230:
+				{
+					position231, tokenIndex231, depth231 := position, tokenIndex, depth
+					{
+						position232, tokenIndex232, depth232 := position, tokenIndex, depth
+						{
+							switch buffer[position] {
+							case ' ':
+								if buffer[position] != rune(' ') {
+									goto l234
+								}
+								position++
+								break
+							case '\t':
+								if buffer[position] != rune('\t') {
+									goto l234
+								}
+								position++
+								break
+							case '\n':
+								if buffer[position] != rune('\n') {
+									goto l234
+								}
+								position++
+								break
+							default:
+								if buffer[position] != rune('\r') {
+									goto l234
+								}
+								position++
+								break
+							}
+						}
+						goto l233
+					l234:
+						position, tokenIndex, depth = position232, tokenIndex232, depth232
+						{
+							position235 := position
+							depth++
+							{
+								position236, tokenIndex236, depth236 := position, tokenIndex, depth
+								{
+									position239, tokenIndex239, depth239 := position, tokenIndex, depth
+									if buffer[position] != rune('#') {
+										goto l241
+									}
+									position++
+									goto l240
+								l241:
+									position, tokenIndex, depth = position239, tokenIndex239, depth239
+									if buffer[position] != rune('-') {
+										goto l238
+									}
+									position++
+									if buffer[position] != rune('-') {
+										goto l238
+									}
+									position++
+								}
+							l240:
+							l242:
+								{
+									position243, tokenIndex243, depth243 := position, tokenIndex, depth
+									{
+										position244, tokenIndex244, depth244 := position, tokenIndex, depth
+										if buffer[position] != rune('\n') {
+											goto l244
+										}
+										position++
+										goto l243
+									l244:
+										position, tokenIndex, depth = position244, tokenIndex244, depth244
+									}
+									if !matchDot() {
+										goto l243
+									}
+									goto l242
+								l243:
+									position, tokenIndex, depth = position243, tokenIndex243, depth243
+								}
+								goto l237
+							l238:
+								position, tokenIndex, depth = position236, tokenIndex236, depth236
+								if buffer[position] != rune('/') {
+									goto l231
+								}
+								position++
+								if buffer[position] != rune('*') {
+									goto l231
+								}
+								position++
+							l245:
+								{
+									position246, tokenIndex246, depth246 := position, tokenIndex, depth
+									{
+										position247, tokenIndex247, depth247 := position, tokenIndex, depth
+										if buffer[position] != rune('*') {
+											goto l247
+										}
+										position++
+										if buffer[position] != rune('/') {
+											goto l247
+										}
+										position++
+										goto l246
+									l247:
+										position, tokenIndex, depth = position247, tokenIndex247, depth247
+									}
+									if !matchDot() {
+										goto l246
+									}
+									goto l245
+								l246:
+									position, tokenIndex, depth = position246, tokenIndex246, depth246
+								}
+								if buffer[position] != rune('*') {
+									goto l231
+								}
+								position++
+								if buffer[position] != rune('/') {
+									goto l231
+								}
+								position++
+							}
+						l237:
+							depth--
+							add(rulecomment, position235)
+						}
+					}
+				l233:
+					goto l230
+				l231:
+					position, tokenIndex, depth = position231, tokenIndex231, depth231
 				}
 				depth--
-				add(rulews, position65)
+				add(rulews, position229)
 			}
 			return true
 		},
-		/* 14 Action0 <- <{ p.EndGlobalPredicates() }> */
+		/* 33 comment <- <((('#' / ('-' '-')) (!'\n' .)*) / (('/' '*') (!('*' '/') .)* ('*' '/')))> */
+		nil,
+		/* 35 Action0 <- <{ p.EndStatement() }> */
+		nil,
+		/* 36 Action1 <- <{ p.SetLabel(buffer[begin:end]) }> */
+		nil,
+		/* 37 Action2 <- <{ p.SetDescription(buffer[begin:end]) }> */
+		nil,
+		/* 38 Action3 <- <{ p.EndGlobalPredicates() }> */
+		nil,
+		/* 39 Action4 <- <{ p.AddGroupColumn() }> */
+		nil,
+		/* 40 Action5 <- <{ p.AddGroupColumn() }> */
+		nil,
+		/* 41 Action6 <- <{ p.BeginPredicates() }> */
+		nil,
+		/* 42 Action7 <- <{ p.EndPredicates() }> */
+		nil,
+		/* 43 Action8 <- <{ p.SetMatchColumn() }> */
+		nil,
+		/* 44 Action9 <- <{ p.SetTolerance() }> */
+		nil,
+		/* 45 Action10 <- <{ p.SetRelativeTolerance() }> */
+		nil,
+		/* 46 Action11 <- <{ p.SetInterpolation() }> */
+		nil,
+		nil,
+		/* 48 Action12 <- <{ p.SetOutlierMethod(buffer[begin:end]) }> */
+		nil,
+		/* 49 Action13 <- <{ p.SetOutlierThreshold() }> */
+		nil,
+		/* 50 Action14 <- <{ p.SetMissingPolicy(buffer[begin:end]) }> */
+		nil,
+		/* 51 Action15 <- <{ p.SetStatistic(buffer[begin:end]) }> */
+		nil,
+		/* 52 Action16 <- <{ p.EndLeft() }> */
+		nil,
+		/* 53 Action17 <- <{ p.SetResultOp(buffer[begin:end]) }> */
+		nil,
+		/* 54 Action18 <- <{ p.SetThreshold() }> */
+		nil,
+		/* 55 Action19 <- <{ p.SetCorrelation(buffer[begin:end]) }> */
+		nil,
+		/* 56 Action20 <- <{ p.SetVariable() }> */
+		nil,
+		/* 57 Action21 <- <{ p.SetCovariate() }> */
+		nil,
+		/* 58 Action22 <- <{ p.SetResultOp(buffer[begin:end]) }> */
+		nil,
+		/* 59 Action23 <- <{ p.SetThreshold() }> */
+		nil,
+		/* 60 Action24 <- <{ p.SetScaling(buffer[begin:end]) }> */
+		nil,
+		/* 61 Action25 <- <{ p.SetVariable() }> */
+		nil,
+		/* 62 Action26 <- <{ p.SetScaleColumn() }> */
+		nil,
+		/* 63 Action27 <- <{ p.SetBaseline() }> */
+		nil,
+		/* 64 Action28 <- <{ p.SetResultOp(buffer[begin:end]) }> */
+		nil,
+		/* 65 Action29 <- <{ p.SetThreshold() }> */
+		nil,
+		/* 66 Action30 <- <{ p.SetThresholdColumn() }> */
+		nil,
+		/* 67 Action31 <- <{ p.EndParetoCandidates() }> */
+		nil,
+		/* 68 Action32 <- <{ p.EndParetoRivals() }> */
+		nil,
+		/* 69 Action33 <- <{ p.AddObjective(buffer[begin:end]) }> */
+		nil,
+		/* 70 Action34 <- <{ p.EndLeft() }> */
+		nil,
+		/* 71 Action35 <- <{ p.EndRight() }> */
+		nil,
+		/* 72 Action36 <- <{ p.SetLowerBound() }> */
+		nil,
+		/* 73 Action37 <- <{ p.SetUpperBound() }> */
+		nil,
+		/* 74 Action38 <- <{ p.EndLeft() }> */
+		nil,
+		/* 75 Action39 <- <{ p.SetOrders() }> */
+		nil,
+		/* 76 Action40 <- <{ p.EndRight() }> */
+		nil,
+		/* 77 Action41 <- <{ p.EndLeft() }> */
+		nil,
+		/* 78 Action42 <- <{ p.SetDistributionTest("dominates") }> */
+		nil,
+		/* 79 Action43 <- <{ p.EndRight() }> */
+		nil,
+		/* 80 Action44 <- <{ p.SetDistributionTest("ks") }> */
+		nil,
+		/* 81 Action45 <- <{ p.EndRight() }> */
+		nil,
+		/* 82 Action46 <- <{ p.SetSignificance() }> */
+		nil,
+		/* 83 Action47 <- <{ p.BeginRanking() }> */
+		nil,
+		/* 84 Action48 <- <{ p.SetRankingColumn() }> */
+		nil,
+		/* 85 Action49 <- <{ p.AddRankingOp(buffer[begin:end]) }> */
+		nil,
+		/* 86 Action50 <- <{ p.AddRankingValue(true) }> */
+		nil,
+		/* 87 Action51 <- <{ p.AddRankingValue(false) }> */
+		nil,
+		/* 88 Action52 <- <{ p.AddRankingValue(true) }> */
+		nil,
+		/* 89 Action53 <- <{ p.EndLeft() }> */
+		nil,
+		/* 90 Action54 <- <{ p.SetResultOp(buffer[begin:end]) }> */
+		nil,
+		/* 91 Action55 <- <{ p.EndRight() }> */
+		nil,
+		/* 92 Action56 <- <{ p.BeginFunctionValue() }> */
+		nil,
+		/* 93 Action57 <- <{ p.EndFunctionValue() }> */
+		nil,
+		/* 94 Action58 <- <{ p.BeginPredicate() }> */
 		nil,
+		/* 95 Action59 <- <{ p.SetPredicateOp(buffer[begin:end]) }> */
 		nil,
-		/* 16 Action1 <- <{ p.SetPredicates(buffer[begin:end]) }> */
+		/* 96 Action60 <- <{ p.EndNumericPredicate() }> */
 		nil,
-		/* 17 Action2 <- <{ p.EndLeft() }> */
+		/* 97 Action61 <- <{ p.EndStringPredicate() }> */
 		nil,
-		/* 18 Action3 <- <{ p.SetResultOp(buffer[begin:end]) }> */
+		/* 98 Action62 <- <{ p.EndParamPredicate() }> */
 		nil,
-		/* 19 Action4 <- <{ p.EndRight() }> */
+		/* 99 Action63 <- <{ p.EndOtherPredicate() }> */
 		nil,
-		/* 20 Action5 <- <{ p.BeginFunctionValue() }> */
+		/* 100 Action64 <- <{ p.SetRelative() }> */
 		nil,
-		/* 21 Action6 <- <{ p.EndFunctionValue() }> */
+		/* 101 Action65 <- <{ p.SetFactor() }> */
 		nil,
-		/* 22 Action7 <- <{ p.SetRelative() }> */
+		/* 102 Action66 <- <{ p.StringValue(buffer[begin:end]) }> */
 		nil,
-		/* 23 Action8 <- <{ p.StringValue(buffer[begin:end]) }> */
+		/* 103 Action67 <- <{ p.StringValue(buffer[begin:end]) }> */
 		nil,
-		/* 24 Action9 <- <{ p.StringValue(buffer[begin:end]) }> */
+		/* 104 Action68 <- <{ p.StringValue(buffer[begin:end]) }> */
 		nil,
 	}
 	p.rules = _rules
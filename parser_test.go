@@ -1,6 +1,7 @@
 package aver
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -112,3 +113,331 @@ func TestInvalidParsing(t *testing.T) {
 	assert.NotNil(t, err)
 
 }
+
+func TestComments(t *testing.T) {
+	input := `
+	# throughput for big clusters
+	for
+	   size > 3 -- smaller ones are noisy
+	   and method = 'ceph' /* only
+	                          ceph */
+	expect
+	   y(x_4='mine') > y(x_4='yours') * 0.9 # ten percent
+	`
+
+	v, err := ParseValidation(input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "size>3 and method='ceph'", v.global)
+	assert.Equal(t, "x_4='mine'", v.left.predicates)
+	assert.Equal(t, "x_4='yours'", v.right.predicates)
+	assert.Equal(t, "0.9", v.relative)
+
+	input = `
+	expect
+	   y > 0 /* unterminated
+	`
+	_, err = ParseValidation(input)
+	assert.NotNil(t, err)
+}
+
+func TestMultipleStatements(t *testing.T) {
+	input := `
+	-- claims made in section 4
+	claim "ceph-overhead": "ceph is within 10% of raw"
+	for
+	   size > 3
+	expect
+	   throughput(method='ceph') > throughput(method='raw') * 0.9
+
+	claim "positive":
+	expect
+	   throughput > 0
+
+	expect
+	   latency < 10
+	`
+
+	vs, err := ParseValidations(input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(vs))
+
+	assert.Equal(t, "ceph-overhead", vs[0].Label)
+	assert.Equal(t, "ceph is within 10% of raw", vs[0].Description)
+	assert.Equal(t, "size>3", vs[0].global)
+	assert.Equal(t, "method='ceph'", vs[0].left.predicates)
+	assert.Equal(t, "0.9", vs[0].relative)
+
+	assert.Equal(t, "positive", vs[1].Label)
+	assert.Equal(t, "", vs[1].Description)
+	assert.Equal(t, "", vs[1].global)
+	assert.Equal(t, "throughput", vs[1].left.funcName)
+
+	assert.Equal(t, "", vs[2].Label)
+	assert.Equal(t, "latency", vs[2].left.funcName)
+	assert.Equal(t, "10", vs[2].right.funcName)
+
+	vs, err = ParseValidations("# nothing to see here\n")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(vs))
+
+	_, err = ParseValidations(`claim "no-statement":`)
+	assert.NotNil(t, err)
+
+	_, err = ParseValidation(input)
+	assert.NotNil(t, err)
+}
+
+func TestForEachParsing(t *testing.T) {
+	v, err := ParseValidation(`
+	for each workload, replication
+	expect
+	   y(x_4='mine') > y(x_4='yours')
+	`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"workload", "replication"}, v.groupBy)
+	assert.Equal(t, "", v.global)
+
+	v, err = ParseValidation(`
+	for x_1 = 1
+	for each workload
+	expect
+	   y(x_4='mine') > y(x_4='yours')
+	`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"workload"}, v.groupBy)
+	assert.Equal(t, "x_1=1", v.global)
+
+	v, err = ParseValidation(`
+	for each = 1
+	expect
+	   y(x_4='mine') > y(x_4='yours')
+	`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(v.groupBy))
+	assert.Equal(t, "each=1", v.global)
+}
+
+func TestPairwiseParsing(t *testing.T) {
+	v, err := ParseValidation(`
+	expect
+	   y(x='a') >= y(x=*other*)
+	`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, ">=", v.op[len(v.op)-2:])
+	assert.Equal(t, "x='a'", v.left.predicates)
+	assert.Equal(t, "x=*other*", v.right.predicates)
+	assert.True(t, v.right.terms[0].other)
+
+	v, err = ParseValidation(`
+	for z = 1
+	rank y by x: a > 'b_c' >= 3 < d4
+	`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "z=1", v.global)
+	assert.Equal(t, "y", v.left.funcName)
+	assert.Equal(t, []predicate{
+		{column: "x", op: "=", literal: "a", quoted: true},
+		{column: "x", op: "=", literal: "b_c", quoted: true},
+		{column: "x", op: "=", literal: "3"},
+		{column: "x", op: "=", literal: "d4", quoted: true},
+	}, v.ranking)
+	assert.Equal(t, []string{">", ">=", "<"}, v.rankOps)
+
+	_, err = ParseValidation("rank y by x: a")
+	assert.NotNil(t, err)
+}
+
+func TestVariabilityParsing(t *testing.T) {
+	v, err := ParseValidation("expect cv(throughput(method='a')) < 0.05")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "cv", v.statistic)
+	assert.Equal(t, "throughput", v.left.funcName)
+	assert.Equal(t, "method='a'", v.left.predicates)
+	assert.Equal(t, "0.05", v.right.funcName)
+
+	v, err = ParseValidation("expect stddev(latency) < 2")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "stddev", v.statistic)
+	assert.Equal(t, "latency", v.left.funcName)
+
+	// a column named like a statistic is still a regular comparison
+	v, err = ParseValidation("expect cv(size=1) > cv(size=2)")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", v.statistic)
+	assert.Equal(t, "cv", v.left.funcName)
+	assert.Equal(t, "size=1", v.left.predicates)
+}
+
+func TestDistributionParsing(t *testing.T) {
+	v, err := ParseValidation("expect latency(method='a') dominates latency(method='b')")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "dominates", v.distribution)
+	assert.Equal(t, "method='a'", v.left.predicates)
+	assert.Equal(t, "method='b'", v.right.predicates)
+
+	v, err = ParseValidation(`
+	expect
+	  latency(method='a') same distribution as latency(method='b') at $alpha
+	`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "ks", v.distribution)
+	assert.Equal(t, "$alpha", v.significance)
+
+	_, err = ParseValidation("expect latency dominates latency at 0.05")
+	assert.NotNil(t, err)
+}
+
+func TestCorrelationParsing(t *testing.T) {
+	v, err := ParseValidation("for workload='read' expect spearman(latency, queue_depth) > -0.5")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "spearman", v.correlation)
+	assert.Equal(t, "latency", v.left.funcName)
+	assert.Equal(t, "queue_depth", v.covariate)
+	assert.Equal(t, "-0.5", v.right.funcName)
+	assert.Equal(t, "workload='read'", v.global)
+
+	// not a correlation coefficient, just a variable that starts like one
+	v, err = ParseValidation("expect correctness(method='a') > correctness(method='b')")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", v.correlation)
+	assert.Equal(t, "correctness", v.left.funcName)
+}
+
+func TestScalingParsing(t *testing.T) {
+	v, err := ParseValidation("expect speedup(throughput, size, baseline=1) >= 0.8 * size")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "speedup", v.scaling)
+	assert.Equal(t, "throughput", v.left.funcName)
+	assert.Equal(t, "size", v.scaleColumn)
+	assert.Equal(t, "1", v.baseline)
+	assert.Equal(t, ">=", strings.TrimSpace(v.op))
+	assert.Equal(t, "0.8", v.right.funcName)
+	assert.Equal(t, "size", v.thresholdColumn)
+
+	v, err = ParseValidation("expect efficiency(throughput, nodes) > $min")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "efficiency", v.scaling)
+	assert.Equal(t, "", v.baseline)
+	assert.Equal(t, "$min", v.right.funcName)
+	assert.Equal(t, "", v.thresholdColumn)
+}
+
+func TestRatioParsing(t *testing.T) {
+	v, err := ParseValidation(
+		"expect throughput(method='a') / throughput(method='b') between 1.8 and $high")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "method='a'", v.left.predicates)
+	assert.Equal(t, "method='b'", v.right.predicates)
+	assert.Equal(t, "1.8", v.lower)
+	assert.Equal(t, "$high", v.upper)
+
+	_, err = ParseValidation("expect throughput(method='a') / throughput(method='b') > 2")
+	assert.NotNil(t, err)
+}
+
+func TestLogScaleParsing(t *testing.T) {
+	v, err := ParseValidation("expect latency(method='b') > latency(method='a') by 10x")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "10", v.factor)
+	assert.Equal(t, "", v.relative)
+
+	v, err = ParseValidation(
+		"expect latency(method='b') within 1 order of magnitude of latency(method='a')")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1", v.orders)
+	assert.Equal(t, "method='b'", v.left.predicates)
+	assert.Equal(t, "method='a'", v.right.predicates)
+}
+
+func TestParetoParsing(t *testing.T) {
+	v, err := ParseValidation(`
+	for batch=8
+	expect
+	  pareto(throughput max, latency min)(method='a') not dominated by (method='b')
+	`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"throughput", "latency"}, v.objectives)
+	assert.Equal(t, []string{"max", "min"}, v.directions)
+	assert.Equal(t, "method='a'", v.left.predicates)
+	assert.Equal(t, "method='b'", v.right.predicates)
+	assert.Equal(t, "batch=8", v.global)
+
+	_, err = ParseValidation("expect pareto(throughput up)(method='a')")
+	assert.NotNil(t, err)
+}
+
+func TestOutliersParsing(t *testing.T) {
+	v, err := ParseValidation(`
+	expect
+	  throughput(method='a') > throughput(method='b')
+	excluding outliers by mad 3.5
+	`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "mad", v.outliers)
+	assert.Equal(t, "3.5", v.outlierThreshold)
+
+	_, err = ParseValidation(
+		"expect throughput(method='a') > throughput(method='b') excluding outliers by max 3")
+	assert.NotNil(t, err)
+}
+
+func TestMissingParsing(t *testing.T) {
+	v, err := ParseValidation(`
+	expect
+	  throughput(method='a') > throughput(method='b')
+	excluding outliers by iqr 1.5
+	on missing skip
+	`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "iqr", v.outliers)
+	assert.Equal(t, "skip", v.missing)
+
+	_, err = ParseValidation(
+		"expect throughput(method='a') > throughput(method='b') on missing ignore")
+	assert.NotNil(t, err)
+}
+
+func TestJoinParsing(t *testing.T) {
+	v, err := ParseValidation(`
+	expect
+	  throughput(method='a') > throughput(method='b')
+	matching load within 1%
+	on missing skip
+	`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "load", v.matchColumn)
+	assert.Equal(t, "1", v.tolerance)
+	assert.True(t, v.relativeTolerance)
+	assert.False(t, v.interpolate)
+	assert.Equal(t, "skip", v.missing)
+
+	v, err = ParseValidation(
+		"expect throughput(method='a') > throughput(method='b') interpolate on load")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "load", v.matchColumn)
+	assert.True(t, v.interpolate)
+}
@@ -0,0 +1,234 @@
+package aver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// a row of a table, as seen by statements that are evaluated point by point
+// outside of the database: the values of the columns that identify the point
+// (as equality predicates) and the values of the numeric columns of interest.
+// A point is missing if any of its numeric values is NULL or empty.
+type point struct {
+	terms   []predicate
+	values  []float64
+	missing bool
+}
+
+// obtains the points in the rows satisfying the given conjunctions. NULL
+// values in the columns that identify a point are left out of its terms.
+func selectPoints(ds Dataset, keys []string, numeric []string,
+	conjunctions ...[]predicate) (points []point, err error) {
+
+	columns := append(append([]string{}, keys...), numeric...)
+	err = filter(ds, conjunctions...).Scan(columns, func(row []interface{}) (err error) {
+		p := point{make([]predicate, 0, len(keys)), make([]float64, len(numeric)), false}
+		for i, c := range keys {
+			if t, ok := equalityPredicate(c, row[i]); ok {
+				p.terms = append(p.terms, t)
+			}
+		}
+		for i, c := range numeric {
+			var missing bool
+			p.values[i], missing, err = numericValue(c, row[len(keys)+i])
+			if err != nil {
+				return
+			}
+			p.missing = p.missing || missing
+		}
+		points = append(points, p)
+		return
+	})
+	return
+}
+
+// converts a value scanned from a numeric column, telling whether it's
+// missing, i.e. NULL or empty (as it happens for empty cells in CSV files)
+func numericValue(column string, value interface{}) (f float64, missing bool, err error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, true, nil
+	case int64:
+		return float64(v), false, nil
+	case float64:
+		return v, false, nil
+	case []byte:
+		return numericValue(column, string(v))
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return 0, true, nil
+		}
+		if f, err = strconv.ParseFloat(strings.TrimSpace(v), 64); err != nil {
+			return 0, false, AverError{
+				"non-numeric value '" + v + "' in column '" + column + "'"}
+		}
+		return f, false, nil
+	}
+	return 0, false, AverError{
+		fmt.Sprintf("unsupported type %T in column '%s'", value, column)}
+}
+
+// returns the points that have no missing values
+func present(points []point) []point {
+	kept := make([]point, 0, len(points))
+	for _, p := range points {
+		if !p.missing {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// checks that the points of both sides of a comparison pair up the same way
+// Holds requires them to in SQL: each side has as many points as their join,
+// regardless of whether their values are missing. Thus, repeated measurements
+// of a configuration can't be compared point by point. Unlike SQL, NULL values
+// of the join columns are taken to be equal.
+func checkSides(left, right []point) error {
+	if len(left) != len(right) {
+		return AverError{"number of values doesn't match for left/right predicates"}
+	}
+	onRight := make(map[string]int)
+	for _, p := range right {
+		onRight[conjunction(p.terms)]++
+	}
+	joined := 0
+	for _, p := range left {
+		joined += onRight[conjunction(p.terms)]
+	}
+	if joined != len(left) {
+		return AverError{
+			"number of values for unpredicated columns doesn't match for left/right sides"}
+	}
+	return nil
+}
+
+// checks that both sides of a comparison have the same configurations, and
+// the same number of values unless they are interpolated. Comparisons whose
+// values are paired up on a match column, or that exclude outliers, have
+// several values for each configuration.
+func checkConfigurations(left, right []point, interpolate bool) error {
+	if len(left) != len(right) && !interpolate {
+		return AverError{"number of values doesn't match for left/right predicates"}
+	}
+	onLeft := make(map[string]bool)
+	for _, p := range left {
+		onLeft[conjunction(p.terms)] = true
+	}
+	onRight := make(map[string]bool)
+	for _, p := range right {
+		if !onLeft[conjunction(p.terms)] {
+			return AverError{
+				"number of values for unpredicated columns doesn't match for left/right sides"}
+		}
+		onRight[conjunction(p.terms)] = true
+	}
+	if len(onLeft) != len(onRight) {
+		return AverError{
+			"number of values for unpredicated columns doesn't match for left/right sides"}
+	}
+	return nil
+}
+
+// a point for which there are values on both sides of a comparison
+type pairedPoint struct {
+	terms []predicate
+	left  float64
+	right float64
+}
+
+// obtains the values of the dependent variable on each side of a comparison
+// and pairs them up on the join columns, the same way Holds does in SQL (see
+// checkSides). Missing values, as well as outliers if the statement excludes
+// them, are removed from each side before pairing values up; since outliers
+// are looked for among the values of each configuration, every value on the
+// left is then paired with every one on the right. Values can also be paired
+// up on a column whose values are close enough, or interpolated (see match).
+func (v Validation) pairPoints(ds Dataset) (
+	pairs []pairedPoint, outliers []Outlier, err error) {
+
+	columns, err := v.joinColumns(ds)
+	if err != nil {
+		return
+	}
+	if v.matchColumn != "" || v.outliers != "" {
+		// all the runs of a configuration are looked at together
+		columns = withoutRuns(ds, columns)
+	}
+	leftNumeric := []string{v.left.funcName}
+	rightNumeric := []string{v.right.funcName}
+	if v.matchColumn != "" {
+		if columns, err = v.withoutMatchColumn(columns); err != nil {
+			return
+		}
+		leftNumeric = append(leftNumeric, v.matchColumn)
+		rightNumeric = append(rightNumeric, v.matchColumn)
+	}
+
+	left, err := selectPoints(ds, columns, leftNumeric, v.left.terms, v.globalTerms)
+	if err != nil {
+		return
+	}
+	if len(left) == 0 {
+		return nil, nil, AverError{"no values associated to left-side predicates"}
+	}
+	right, err := selectPoints(ds, columns, rightNumeric, v.right.terms, v.globalTerms)
+	if err != nil {
+		return
+	}
+	if len(right) == 0 {
+		return nil, nil, AverError{"no values associated to right-side predicates"}
+	}
+	if v.matchColumn == "" && v.outliers == "" {
+		if err = checkSides(left, right); err != nil {
+			return
+		}
+	} else if err = checkConfigurations(left, right, v.interpolate); err != nil {
+		return
+	}
+
+	keys := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, p := range left {
+		if key := conjunction(p.terms); !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+
+	left, leftOutliers, err := v.excludeOutliers(present(left), v.left)
+	if err != nil {
+		return
+	}
+	right, rightOutliers, err := v.excludeOutliers(present(right), v.right)
+	if err != nil {
+		return
+	}
+	outliers = append(leftOutliers, rightOutliers...)
+
+	leftPoints := make(map[string][]point)
+	for _, p := range left {
+		leftPoints[conjunction(p.terms)] = append(leftPoints[conjunction(p.terms)], p)
+	}
+	rightPoints := make(map[string][]point)
+	for _, p := range right {
+		rightPoints[conjunction(p.terms)] = append(rightPoints[conjunction(p.terms)], p)
+	}
+	for _, key := range keys {
+		for _, l := range leftPoints[key] {
+			if v.matchColumn == "" {
+				for _, r := range rightPoints[key] {
+					pairs = append(pairs, pairedPoint{l.terms, l.values[0], r.values[0]})
+				}
+				continue
+			}
+			matched, err := v.match(l, rightPoints[key])
+			if err != nil {
+				return nil, nil, err
+			}
+			pairs = append(pairs, matched...)
+		}
+	}
+	return
+}
@@ -0,0 +1,61 @@
+package aver
+
+import (
+	"math"
+	"strconv"
+)
+
+// RangeResult is the outcome of a ratio statement (e.g.
+// 'throughput(method='a') / throughput(method='b') between 1.8 and 2.2'),
+// one per group or pair
+type RangeResult struct {
+	// Group is the 'for each' group the result belongs to (if any)
+	Group string
+	// Left and Right are the predicates of the numerator and denominator
+	Left  string
+	Right string
+	// Min and Max are the smallest and largest ratio observed over Count
+	// points
+	Min   float64
+	Max   float64
+	Count int
+	Holds bool
+}
+
+// evaluates a ratio statement. The values of each side are paired up in the
+// same way as for a comparison, and the statement holds if the ratio of every
+// pair is within bounds (inclusive).
+func (v Validation) ratioRange(ds Dataset) (
+	r RangeResult, outliers []Outlier, err error) {
+
+	if v.left.funcName != v.right.funcName {
+		return r, nil, AverError{
+			"Validation comparison; " + v.left.funcName + " distinct to " + v.right.funcName}
+	}
+	lower, err := strconv.ParseFloat(v.lower, 64)
+	if err != nil {
+		return r, nil, AverError{"Expecting numeric lower bound; got " + v.lower}
+	}
+	upper, err := strconv.ParseFloat(v.upper, 64)
+	if err != nil {
+		return r, nil, AverError{"Expecting numeric upper bound; got " + v.upper}
+	}
+	if lower > upper {
+		return r, nil, AverError{"lower bound " + v.lower + " is greater than upper bound " + v.upper}
+	}
+
+	pairs, outliers, err := v.pairPoints(ds)
+	if err != nil {
+		return
+	}
+
+	r = RangeResult{
+		Left: v.left.predicates, Right: v.right.predicates,
+		Min: math.Inf(1), Max: math.Inf(-1), Count: len(pairs)}
+	for _, p := range pairs {
+		ratio := p.left / p.right
+		r.Min, r.Max = math.Min(r.Min, ratio), math.Max(r.Max, ratio)
+	}
+	r.Holds = lower <= r.Min && r.Max <= upper
+	return
+}
@@ -0,0 +1,73 @@
+package aver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatioBetween(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadWorkloadTable(t, db)
+
+	r, err := Evaluate(`
+	for workload='read'
+	expect
+	  throughput(method='a') / throughput(method='b') between 1.8 and 2.2
+	`, db, "workloads")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 1, len(r.Ranges))
+	assert.Equal(t, "method='a'", r.Ranges[0].Left)
+	assert.Equal(t, "method='b'", r.Ranges[0].Right)
+	assert.Equal(t, 2, r.Ranges[0].Count)
+	assert.InDelta(t, 141.0/70, r.Ranges[0].Min, 1e-9)
+	assert.InDelta(t, 152.0/72, r.Ranges[0].Max, 1e-9)
+
+	r, err = Evaluate(`
+	for each workload
+	expect
+	  throughput(method='a') / throughput(method='b') between $low and $high
+	`, db, "workloads", Params{"low": 1.8, "high": 2.2})
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []GroupResult{
+		{"workload='read'", true},
+		{"workload='write'", false},
+	}, r.Groups)
+	assert.InDelta(t, 136.0/149, r.Ranges[1].Min, 1e-9)
+	assert.InDelta(t, 142.0/70, r.Ranges[1].Max, 1e-9)
+}
+
+func TestRatioErrors(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadWorkloadTable(t, db)
+
+	_, err := Holds(
+		"expect throughput(method='a') / throughput(method='b') between 2.2 and 1.8",
+		db, "workloads")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: lower bound 2.2 is greater than upper bound 1.8", err.Error())
+
+	_, err = Holds(
+		"expect throughput(method='a') / throughput(method='c') between 1.8 and 2.2",
+		db, "workloads")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no values associated to right-side predicates", err.Error())
+
+	_, err = Holds(
+		"expect throughput(method='a') / throughput(method='b' and size=1) between 1.8 and 2.2",
+		db, "workloads")
+
+	assert.NotNil(t, err)
+	assert.Equal(t,
+		"aver: number of values doesn't match for left/right predicates", err.Error())
+}
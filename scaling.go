@@ -0,0 +1,129 @@
+package aver
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PointResult is the verdict for one of the points of a statement that is
+// evaluated point by point, e.g. 'speedup(throughput, size) >= 0.8 * size'
+type PointResult struct {
+	// Group is the 'for each' group the point belongs to (if any)
+	Group string
+	// Point is the conjunction of predicates that identifies the point, e.g.
+	// "workload='read' and size=4"
+	Point string
+	// Value is the one observed for the point (e.g. its speedup, or the factor
+	// between both sides of a log-scale comparison) and Bound the one it's
+	// compared against (e.g. 0.8 * 4, or 10 for 'by 10x')
+	Value float64
+	Bound float64
+	Holds bool
+}
+
+// evaluates a scalability statement. The speedup of a point is the ratio of
+// its value to the one of the baseline point of its group, i.e. the point
+// with the same values on every column other than the scaled one, and whose
+// value on the scaled column is the baseline (the smallest value of the column
+// if no baseline is given). Efficiency is the speedup divided by the scale
+// factor (e.g. 'size / baseline'). The dependent variable is expected to be
+// one where higher values are better, such as throughput.
+func (v Validation) scale(ds Dataset) (
+	holds bool, results []PointResult, err error) {
+
+	threshold, err := strconv.ParseFloat(v.right.funcName, 64)
+	if err != nil {
+		return false, nil, AverError{
+			"Expecting numeric threshold for " + v.scaling + "; got " + v.right.funcName}
+	}
+
+	columns, err := v.joinColumns(ds)
+	if err != nil {
+		return
+	}
+	keys := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if c != v.scaleColumn {
+			keys = append(keys, c)
+		}
+	}
+	numeric := []string{v.left.funcName, v.scaleColumn}
+	if v.thresholdColumn != "" {
+		numeric = append(numeric, v.thresholdColumn)
+	}
+
+	points, err := selectPoints(ds, keys, numeric, v.globalTerms)
+	if err != nil {
+		return
+	}
+	points = present(points)
+	if len(points) == 0 {
+		return false, nil, AverError{"no values associated to '" + v.left.funcName + "'"}
+	}
+
+	baseline := math.Inf(1)
+	if v.baseline != "" {
+		if baseline, err = strconv.ParseFloat(v.baseline, 64); err != nil {
+			return false, nil, AverError{"Expecting numeric baseline; got " + v.baseline}
+		}
+	} else {
+		for _, p := range points {
+			baseline = math.Min(baseline, p.values[1])
+		}
+	}
+	base := predicate{column: v.scaleColumn, op: "=",
+		literal: strconv.FormatFloat(baseline, 'f', -1, 64)}
+
+	// value of the baseline point of each group
+	baselines := make(map[string]float64)
+	for _, p := range points {
+		if p.values[1] != baseline {
+			continue
+		}
+		group := conjunction(p.terms)
+		if _, ok := baselines[group]; ok {
+			return false, nil, AverError{
+				"more than one baseline row with " + base.String() + inGroupOf(group)}
+		}
+		if p.values[0] == 0 {
+			return false, nil, AverError{
+				"value of '" + v.left.funcName + "' is 0 for baseline row with " +
+					base.String() + inGroupOf(group)}
+		}
+		baselines[group] = p.values[0]
+	}
+
+	holds = true
+	for _, p := range points {
+		group := conjunction(p.terms)
+		b, ok := baselines[group]
+		if !ok {
+			return false, nil, AverError{"no baseline row with " + base.String() + inGroupOf(group)}
+		}
+		r := PointResult{
+			Point: conjunction(append(append([]predicate{}, p.terms...), predicate{
+				column: v.scaleColumn, op: "=",
+				literal: strconv.FormatFloat(p.values[1], 'f', -1, 64)})),
+			Value: p.values[0] / b,
+			Bound: threshold,
+		}
+		if v.scaling == "efficiency" {
+			r.Value = r.Value * baseline / p.values[1]
+		}
+		if v.thresholdColumn != "" {
+			r.Bound *= p.values[2]
+		}
+		r.Holds = compare(r.Value, strings.TrimSpace(v.op), r.Bound)
+		holds = holds && r.Holds
+		results = append(results, r)
+	}
+	return
+}
+
+func inGroupOf(group string) string {
+	if group == "" {
+		return ""
+	}
+	return " in group " + group
+}
@@ -0,0 +1,115 @@
+package aver
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadScalabilityTable(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(`
+		CREATE TABLE scalability (
+			workload VARCHAR(255),
+			size INT,
+			throughput FLOAT
+		)
+	`)
+	assert.Nil(t, err)
+
+	for _, row := range []string{
+		"'read', 1, 100", "'read', 2, 190", "'read', 4, 360", "'read', 8, 700",
+		"'write', 1, 50", "'write', 2, 90", "'write', 4, 150", "'write', 8, 200",
+	} {
+		_, err = db.Exec("INSERT INTO scalability VALUES(" + row + ")")
+		assert.Nil(t, err)
+	}
+}
+
+func TestSpeedup(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadScalabilityTable(t, db)
+
+	r, err := Evaluate(
+		"for workload='read' expect speedup(throughput, size, baseline=1) >= 0.8 * size",
+		db, "scalability")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 4, len(r.Points))
+	assert.Equal(t, "workload='read' and size=8", r.Points[3].Point)
+	assert.Equal(t, 7.0, r.Points[3].Value)
+	assert.InDelta(t, 6.4, r.Points[3].Bound, 1e-9)
+
+	r, err = Evaluate(`
+	for each workload
+	expect
+	  speedup(throughput, size) >= 0.8 * size
+	`, db, "scalability")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []GroupResult{
+		{"workload='read'", true},
+		{"workload='write'", false},
+	}, r.Groups)
+	assert.Equal(t, 8, len(r.Points))
+	assert.Equal(t, "workload='write'", r.Points[7].Group)
+	assert.False(t, r.Points[7].Holds)
+
+	// baseline rows are found within each workload
+	holds, err := Holds("expect speedup(throughput, size, baseline=$base) > 1.5",
+		db, "scalability", Params{"base": 1})
+
+	assert.Nil(t, err)
+	assert.False(t, holds)
+
+	holds, err = Holds("for size>1 expect speedup(throughput, size, baseline=2) > 0.9",
+		db, "scalability")
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+}
+
+func TestEfficiency(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadScalabilityTable(t, db)
+
+	r, err := Evaluate(
+		"for workload='read' expect efficiency(throughput, size) >= 0.85", db, "scalability")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.InDelta(t, 0.875, r.Points[3].Value, 1e-9)
+
+	holds, err := Holds("expect efficiency(throughput, size) >= 0.85", db, "scalability")
+
+	assert.Nil(t, err)
+	assert.False(t, holds)
+}
+
+func TestSpeedupErrors(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadScalabilityTable(t, db)
+
+	_, err := Holds("expect speedup(throughput, size, baseline=3) > 1", db, "scalability")
+
+	assert.NotNil(t, err)
+	assert.Equal(t,
+		"aver: no baseline row with size=3 in group workload='read'", err.Error())
+
+	_, err = db.Exec("INSERT INTO scalability VALUES('read', 1, 110)")
+	assert.Nil(t, err)
+
+	_, err = Holds("expect speedup(throughput, size) > 1", db, "scalability")
+
+	assert.NotNil(t, err)
+	assert.Equal(t,
+		"aver: more than one baseline row with size=1 in group workload='read'", err.Error())
+}
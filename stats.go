@@ -0,0 +1,230 @@
+package aver
+
+// This file contains the statistics used by variability and distribution
+// statements. All functions expect non-empty slices of values.
+
+import (
+	"math"
+	"sort"
+)
+
+func mean(xs []float64) float64 {
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// sample standard deviation (0 for a single value)
+func stddev(xs []float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	m := mean(xs)
+	sum := 0.0
+	for _, x := range xs {
+		sum += (x - m) * (x - m)
+	}
+	return math.Sqrt(sum / float64(len(xs)-1))
+}
+
+// coefficient of variation, i.e. the standard deviation relative to the mean
+func cv(xs []float64) float64 {
+	return stddev(xs) / math.Abs(mean(xs))
+}
+
+// q-th quantile (0 <= q <= 1), linearly interpolating between the two
+// closest ranks
+func quantile(xs []float64, q float64) float64 {
+	sorted := append([]float64{}, xs...)
+	sort.Float64s(sorted)
+
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	return sorted[lower] + (pos-float64(lower))*(sorted[upper]-sorted[lower])
+}
+
+func median(xs []float64) float64 {
+	return quantile(xs, 0.5)
+}
+
+// interquartile range
+func iqr(xs []float64) float64 {
+	return quantile(xs, 0.75) - quantile(xs, 0.25)
+}
+
+// median absolute deviation (unscaled)
+func mad(xs []float64) float64 {
+	m := median(xs)
+	deviations := make([]float64, len(xs))
+	for i, x := range xs {
+		deviations[i] = math.Abs(x - m)
+	}
+	return median(deviations)
+}
+
+// fraction of values in a sorted slice that are less than or equal to x
+func ecdf(sorted []float64, x float64) float64 {
+	return float64(sort.Search(len(sorted), func(i int) bool {
+		return sorted[i] > x
+	})) / float64(len(sorted))
+}
+
+// returns the largest difference F_a(x) - F_b(x) between the empirical
+// distribution functions of two samples, and the largest one in absolute
+// value (i.e. the Kolmogorov-Smirnov statistic)
+func ecdfDistance(a, b []float64) (maxDiff float64, maxAbsDiff float64) {
+	sortedA := append([]float64{}, a...)
+	sortedB := append([]float64{}, b...)
+	sort.Float64s(sortedA)
+	sort.Float64s(sortedB)
+
+	maxDiff = math.Inf(-1)
+	for _, x := range append(sortedA, sortedB...) {
+		diff := ecdf(sortedA, x) - ecdf(sortedB, x)
+		maxDiff = math.Max(maxDiff, diff)
+		maxAbsDiff = math.Max(maxAbsDiff, math.Abs(diff))
+	}
+	return
+}
+
+// whether sample a first-order stochastically dominates sample b, i.e.
+// F_a(x) <= F_b(x) for every x, with strict inequality for at least one x.
+// The returned violation is the largest amount by which F_a exceeds F_b (0
+// if a dominates b)
+func dominates(a, b []float64) (holds bool, violation float64) {
+	maxDiff, maxAbsDiff := ecdfDistance(a, b)
+	if maxDiff > 0 {
+		return false, maxDiff
+	}
+	return maxAbsDiff > 0, 0
+}
+
+// two-sample Kolmogorov-Smirnov test. Returns the D statistic and its
+// (asymptotic) p-value, as described in Numerical Recipes (14.3)
+func ksTest(a, b []float64) (d float64, pValue float64) {
+	_, d = ecdfDistance(a, b)
+	ne := float64(len(a)*len(b)) / float64(len(a)+len(b))
+	sqrtNe := math.Sqrt(ne)
+	return d, kolmogorovQ((sqrtNe + 0.12 + 0.11/sqrtNe) * d)
+}
+
+// complementary cumulative Kolmogorov distribution
+func kolmogorovQ(lambda float64) float64 {
+	const eps1, eps2 = 0.001, 1.0e-8
+
+	a2 := -2.0 * lambda * lambda
+	fac, sum, previous := 2.0, 0.0, 0.0
+	for j := 1; j <= 100; j++ {
+		term := fac * math.Exp(a2*float64(j*j))
+		sum += term
+		if math.Abs(term) <= eps1*previous || math.Abs(term) <= eps2*sum {
+			return sum
+		}
+		fac = -fac
+		previous = math.Abs(term)
+	}
+	// the series doesn't converge for small values of lambda
+	return 1.0
+}
+
+// Pearson's correlation coefficient. It's NaN if either sample is constant
+func pearson(xs, ys []float64) float64 {
+	mx, my := mean(xs), mean(ys)
+	sxy, sxx, syy := 0.0, 0.0, 0.0
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	return sxy / math.Sqrt(sxx*syy)
+}
+
+// ranks of the values of a sample (starting at 1), where tied values get the
+// average of the ranks they span
+func ranks(xs []float64) []float64 {
+	order := make([]int, len(xs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return xs[order[i]] < xs[order[j]] })
+
+	r := make([]float64, len(xs))
+	for i := 0; i < len(order); {
+		j := i
+		for j+1 < len(order) && xs[order[j+1]] == xs[order[i]] {
+			j++
+		}
+		for k := i; k <= j; k++ {
+			r[order[k]] = float64(i+j)/2 + 1
+		}
+		i = j + 1
+	}
+	return r
+}
+
+// Spearman's rank correlation coefficient, i.e. Pearson's coefficient of the
+// ranks
+func spearman(xs, ys []float64) float64 {
+	return pearson(ranks(xs), ranks(ys))
+}
+
+// Kendall's tau-b rank correlation coefficient, which accounts for ties
+func kendall(xs, ys []float64) float64 {
+	concordant, discordant, tiesX, tiesY := 0.0, 0.0, 0.0, 0.0
+	for i := range xs {
+		for j := i + 1; j < len(xs); j++ {
+			dx, dy := xs[i]-xs[j], ys[i]-ys[j]
+			switch {
+			case dx == 0 && dy == 0:
+			case dx == 0:
+				tiesX++
+			case dy == 0:
+				tiesY++
+			case (dx > 0) == (dy > 0):
+				concordant++
+			default:
+				discordant++
+			}
+		}
+	}
+	return (concordant - discordant) /
+		math.Sqrt((concordant+discordant+tiesX)*(concordant+discordant+tiesY))
+}
+
+// correlation coefficients that can be used in correlation statements
+var correlations = map[string]func([]float64, []float64) float64{
+	"corr":     pearson,
+	"spearman": spearman,
+	"kendall":  kendall,
+}
+
+// statistics that can be used in variability statements
+var statistics = map[string]func([]float64) float64{
+	"stddev": stddev,
+	"cv":     cv,
+	"iqr":    iqr,
+	"mad":    mad,
+}
+
+// evaluates 'a <op> b'
+func compare(a float64, op string, b float64) bool {
+	switch op {
+	case "=":
+		return a == b
+	case "<>":
+		return a != b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	}
+	return false
+}
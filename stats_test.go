@@ -0,0 +1,90 @@
+package aver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatistics(t *testing.T) {
+	xs := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	assert.Equal(t, 5.0, mean(xs))
+	assert.InDelta(t, 2.138, stddev(xs), 0.001)
+	assert.InDelta(t, 0.4276, cv(xs), 0.0001)
+	assert.Equal(t, 4.5, median(xs))
+	assert.Equal(t, 2.0, quantile(xs, 0))
+	assert.Equal(t, 9.0, quantile(xs, 1))
+	assert.Equal(t, 1.5, iqr(xs))
+	assert.Equal(t, 0.5, mad(xs))
+
+	assert.Equal(t, 0.0, stddev([]float64{3}))
+	assert.Equal(t, 3.0, median([]float64{3}))
+}
+
+func TestCompare(t *testing.T) {
+	assert.True(t, compare(1, "<", 2))
+	assert.True(t, compare(2, "<=", 2))
+	assert.True(t, compare(2, "=", 2))
+	assert.True(t, compare(1, "<>", 2))
+	assert.False(t, compare(1, ">", 2))
+	assert.False(t, compare(1, ">=", 2))
+	assert.False(t, compare(1, "~", 2))
+}
+
+func TestDominance(t *testing.T) {
+	holds, violation := dominates([]float64{3, 4, 5}, []float64{1, 2, 3})
+	assert.True(t, holds)
+	assert.Equal(t, 0.0, violation)
+
+	holds, violation = dominates([]float64{1, 2, 3}, []float64{3, 4, 5})
+	assert.False(t, holds)
+	assert.InDelta(t, 2.0/3, violation, 1e-9)
+
+	// identical samples don't dominate each other
+	holds, _ = dominates([]float64{1, 2, 3}, []float64{1, 2, 3})
+	assert.False(t, holds)
+
+	// crossing distributions
+	holds, _ = dominates([]float64{0, 10}, []float64{4, 5})
+	assert.False(t, holds)
+}
+
+func TestKolmogorovSmirnov(t *testing.T) {
+	a := []float64{0.61, 0.29, 0.06, 0.59, -1.73, -0.74, 0.51, -0.56, 0.39, 1.64, 0.05, -0.06, 0.64, -0.82, 0.37, 1.77, 1.09, -1.28, 2.36, 1.31, 1.05, -0.32, -0.4, 1.06, -2.47}
+	b := []float64{2.2, 1.66, 1.38, 0.2, 0.36, 0, 0.96, 1.56, 0.44, 1.5, -0.3, 0.66, 2.31, 3.29, -0.27, -0.37, 0.38, 0.7, 0.52, -0.71}
+
+	d, p := ksTest(a, b)
+	assert.InDelta(t, 0.23, d, 1e-9)
+	assert.InDelta(t, 0.541, p, 0.001)
+
+	d, p = ksTest(a, a)
+	assert.Equal(t, 0.0, d)
+	assert.Equal(t, 1.0, p)
+
+	_, p = ksTest([]float64{1, 2, 3, 4, 5, 6, 7, 8}, []float64{11, 12, 13, 14, 15, 16, 17, 18})
+	assert.True(t, p < 0.01)
+}
+
+func TestCorrelations(t *testing.T) {
+	xs := []float64{1, 2, 3, 4, 5}
+	ys := []float64{2, 4, 5, 4, 5}
+
+	assert.InDelta(t, 0.7746, pearson(xs, ys), 1e-4)
+	assert.Equal(t, []float64{1, 2.5, 4.5, 2.5, 4.5}, ranks(ys))
+	assert.InDelta(t, 0.7379, spearman(xs, ys), 1e-4)
+	assert.InDelta(t, 0.6708, kendall(xs, ys), 1e-4)
+
+	// monotonic but not linear
+	squares := []float64{1, 4, 9, 16, 25}
+	assert.True(t, pearson(xs, squares) < 1)
+	assert.InDelta(t, 1.0, spearman(xs, squares), 1e-9)
+	assert.InDelta(t, 1.0, kendall(xs, squares), 1e-9)
+
+	reversed := []float64{5, 4, 3, 2, 1}
+	assert.InDelta(t, -1.0, pearson(xs, reversed), 1e-9)
+	assert.InDelta(t, -1.0, kendall(xs, reversed), 1e-9)
+
+	assert.True(t, math.IsNaN(pearson(xs, []float64{3, 3, 3, 3, 3})))
+}
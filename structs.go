@@ -0,0 +1,183 @@
+package aver
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// HoldsOn checks a validation statement against a slice of structs, each of
+// which is a row of the table the statement refers to. It has the same
+// semantics as Holds, but the values are held in memory (see
+// NewStructDataset for how fields are mapped to columns). Placeholders in the
+// statement are bound to the values given in params.
+func HoldsOn(validation string, rows interface{}, params ...Params) (b bool, err error) {
+	r, err := EvaluateOn(validation, rows, params...)
+	if err != nil {
+		return
+	}
+	return r.Holds, nil
+}
+
+// EvaluateOn is like HoldsOn but reports the outcome of the evaluation the
+// way Evaluate does
+func EvaluateOn(validation string, rows interface{}, params ...Params) (r Result, err error) {
+	ds, err := NewStructDataset(rows)
+	if err != nil {
+		return
+	}
+	return EvaluateDataset(validation, ds, Options{Params: MergeParams(params...)})
+}
+
+// NewStructDataset returns a Dataset holding a slice (or array, or a pointer
+// to either) of structs or pointers to structs. Every exported field is a column, named after the
+// field (in lower case) unless it's tagged with the name of the column, e.g.
+//
+//	type Result struct {
+//		Method     string  `aver:"method"`
+//		Throughput float64 `aver:"throughput"`
+//		Notes      string  `aver:"-"`
+//	}
+//
+// where the '-' tag leaves the field out. Fields of embedded structs are
+// columns of the table as well. Fields can be strings, booleans or numbers of
+// any type, as well as pointers to those, in which case a nil pointer stands
+// for a missing value. Untagged fields of any other type are ignored.
+func NewStructDataset(rows interface{}) (Dataset, error) {
+	value := reflect.ValueOf(rows)
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, AverError{"expecting a slice of structs; got " + typeName(rows)}
+	}
+	elem := value.Type().Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, AverError{"expecting a slice of structs; got " + typeName(rows)}
+	}
+
+	fields, err := structFields(elem, nil)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, len(fields))
+	for i, f := range fields {
+		columns[i] = f.column
+	}
+
+	values := make([][]interface{}, value.Len())
+	for i := range values {
+		row := value.Index(i)
+		if row.Kind() == reflect.Ptr {
+			if row.IsNil() {
+				return nil, AverError{"nil row at index " + strconv.Itoa(i)}
+			}
+			row = row.Elem()
+		}
+		values[i] = make([]interface{}, len(fields))
+		for j, f := range fields {
+			values[i][j] = fieldValue(row.FieldByIndex(f.index))
+		}
+	}
+	return NewMemoryDataset(columns, values)
+}
+
+// a field of a struct that is mapped to a column
+type structField struct {
+	column string
+	index  []int
+}
+
+// obtains the fields of a struct that are mapped to columns, following
+// embedded structs
+func structFields(t reflect.Type, index []int) (fields []structField, err error) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("aver")
+		if f.PkgPath != "" && !f.Anonymous || tag == "-" {
+			continue
+		}
+		fieldIndex := append(append([]int{}, index...), i)
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && tag == "" && ft.Kind() == reflect.Struct {
+			if f.Type.Kind() == reflect.Ptr {
+				return nil, AverError{"embedded pointer to struct in field " + f.Name +
+					" is not supported"}
+			}
+			embedded, err := structFields(ft, fieldIndex)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embedded...)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if !isScalar(ft.Kind()) {
+			if tag != "" {
+				return nil, AverError{"unsupported type " + f.Type.String() +
+					" of field " + f.Name + " (column '" + tag + "')"}
+			}
+			continue
+		}
+
+		column := tag
+		if column == "" {
+			column = strings.ToLower(f.Name)
+		}
+		for _, existing := range fields {
+			if strings.EqualFold(existing.column, column) {
+				return nil, AverError{"more than one field for column '" + column + "'"}
+			}
+		}
+		fields = append(fields, structField{column, fieldIndex})
+	}
+	return
+}
+
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// converts the value of a field to one that NewMemoryDataset accepts. Named
+// types (e.g. time.Duration) are converted to their underlying type.
+func fieldValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	}
+	return int64(v.Uint())
+}
+
+func typeName(value interface{}) string {
+	if value == nil {
+		return "nil"
+	}
+	return reflect.TypeOf(value).String()
+}
@@ -0,0 +1,141 @@
+package aver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type benchmarkConfig struct {
+	Size   int    `aver:"size"`
+	Method string `aver:"method"`
+}
+
+type benchmarkResult struct {
+	benchmarkConfig
+	Throughput float64 `aver:"throughput"`
+	Notes      string  `aver:"-"`
+	Labels     []string
+	run        int
+}
+
+type latencyResult struct {
+	Method  string
+	Latency *time.Duration
+}
+
+func TestHoldsOn(t *testing.T) {
+	results := []benchmarkResult{
+		{benchmarkConfig{1, "a"}, 141, "", nil, 1},
+		{benchmarkConfig{1, "b"}, 70, "", nil, 1},
+		{benchmarkConfig{2, "a"}, 152, "", nil, 2},
+		{benchmarkConfig{2, "b"}, 72, "", nil, 2},
+	}
+
+	holds, err := HoldsOn(
+		"expect throughput(method='a') > throughput(method='b') * 2", results)
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+
+	holds, err = HoldsOn(
+		"for size=$s expect throughput(method='a') > throughput(method='b') * 2.1",
+		&results, Params{"s": 2})
+
+	assert.Nil(t, err)
+	assert.True(t, holds)
+
+	pointers := []*benchmarkResult{&results[0], &results[1]}
+	holds, err = HoldsOn("expect throughput(method='a') < throughput(method='b')", pointers)
+
+	assert.Nil(t, err)
+	assert.False(t, holds)
+
+	// untagged fields are named after the field, and nil pointers are missing
+	fast, slow := 2*time.Millisecond, 5*time.Millisecond
+	r, err := EvaluateOn("expect latency < 4000000", []latencyResult{
+		{"a", &fast}, {"b", &slow}, {"c", nil},
+	})
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, 1, r.Missing)
+
+	r, err = EvaluateOn("for method<>'b' expect latency < 4000000 on missing skip",
+		[]latencyResult{{"a", &fast}, {"b", &slow}, {"c", nil}})
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+}
+
+// repeated measurements of a configuration are rejected, as they are by Holds,
+// unless the run they belong to is a column
+func TestHoldsOnRepetitions(t *testing.T) {
+	results := []benchmarkResult{
+		{benchmarkConfig{1, "a"}, 141, "", nil, 1},
+		{benchmarkConfig{1, "b"}, 70, "", nil, 1},
+		{benchmarkConfig{1, "a"}, 60, "", nil, 2},
+		{benchmarkConfig{1, "b"}, 75, "", nil, 2},
+	}
+
+	holds, err := HoldsOn("expect throughput(method='a') > throughput(method='b')", results)
+
+	assert.NotNil(t, err)
+	assert.False(t, holds)
+	assert.Equal(t,
+		"aver: number of values for unpredicated columns doesn't match for left/right sides",
+		err.Error())
+
+	type run struct {
+		benchmarkResult
+		Run int `aver:"run"`
+	}
+	runs := make([]run, len(results))
+	for i, r := range results {
+		runs[i] = run{r, r.run}
+	}
+
+	r, err := EvaluateOn("expect throughput(method='a') > throughput(method='b')", runs)
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, []PointResult{
+		{"", "method='a' and size=1 and run=2", 60, 75, false},
+	}, r.Counterexamples)
+}
+
+func TestStructDataset(t *testing.T) {
+	ds, err := NewStructDataset([]benchmarkResult{})
+	assert.Nil(t, err)
+
+	columns, err := ds.Schema()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"size", "method", "throughput"}, columns)
+
+	_, err = NewStructDataset(benchmarkResult{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: expecting a slice of structs; got aver.benchmarkResult", err.Error())
+
+	_, err = NewStructDataset([]int{1, 2})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: expecting a slice of structs; got []int", err.Error())
+
+	_, err = NewStructDataset([]*benchmarkResult{nil})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: nil row at index 0", err.Error())
+
+	_, err = NewStructDataset([]struct {
+		Samples []float64 `aver:"samples"`
+	}{})
+	assert.NotNil(t, err)
+	assert.Equal(t,
+		"aver: unsupported type []float64 of field Samples (column 'samples')", err.Error())
+
+	_, err = NewStructDataset([]struct {
+		Throughput float64
+		Rate       float64 `aver:"throughput"`
+	}{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: more than one field for column 'throughput'", err.Error())
+}
@@ -0,0 +1,152 @@
+package aver
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// NewSysbenchDataset reads the (text) output of sysbench 1.0 or later into a
+// Dataset held in memory, with one row for each run of sysbench, as given by
+// the 'sysbench <version>' line it starts with (anything before the first one
+// is ignored). Columns are:
+//
+//   - version: the version of sysbench
+//   - one for each option listed at the beginning of the run, e.g. threads
+//     (for 'Number of threads') or prime_numbers_limit
+//   - one for each statistic reported at the end of the run, named after the
+//     line it is reported in, e.g. transactions, total_number_of_events or
+//     events_per_second. Statistics listed under a subsection are prefixed by
+//     its name (e.g. queries_performed_read), and those listed under a section
+//     that gives a unit by the name and unit of the section (e.g.
+//     latency_ms_avg or latency_ms_95th_percentile).
+//
+// Statistics with a rate get one more column for it (e.g. transactions_per_sec
+// for '10000 (999.27 per sec.)'), those with a unit are suffixed by it (e.g.
+// total_time_s for '10.0059s'), and pairs of them are split into two columns
+// (e.g. events_avg and events_stddev for 'events (avg/stddev)').
+func NewSysbenchDataset(r io.Reader) (Dataset, error) {
+	rxRate := regexp.MustCompile(`^(\S+)\s+\((\S+) per sec(?:ond)?\.?\)$`)
+	rxUnit := regexp.MustCompile(`^(-?[0-9]+(?:\.[0-9]+)?)([a-zA-Z]+)$`)
+	rxPair := regexp.MustCompile(`^(.*?)\s*\(([^/()]+)/([^/()]+)\)$`)
+	rxSection := regexp.MustCompile(`^.*\S\s*\([^)]+\)$`)
+
+	var t tableBuilder
+	metrics := make([]string, 0)
+	var values map[int]interface{}
+	flush := func() {
+		if len(values) > 0 {
+			t.add(values)
+		}
+		values = make(map[int]interface{})
+	}
+	metric := func(name string, value interface{}) {
+		if !t.has(name) {
+			metrics = append(metrics, name)
+		}
+		values[t.column(name)] = value
+	}
+
+	type section struct {
+		indent int
+		prefix string
+	}
+	var sections []section
+	options := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimLeft(line, " \t")
+		indent := len(line) - len(trimmed)
+
+		switch {
+		case strings.HasPrefix(line, "sysbench "):
+			flush()
+			sections = nil
+			options = false
+			values[t.column("version")] = strings.Fields(line)[1]
+			continue
+		case trimmed == "Running the test with following options:":
+			options = true
+			continue
+		case trimmed == "Threads started!":
+			options = false
+			continue
+		case strings.HasPrefix(trimmed, "["):
+			// intermediate results given by --report-interval
+			continue
+		}
+
+		colon := strings.Index(trimmed, ":")
+		if values == nil || colon < 0 {
+			// not part of the output of a run
+			continue
+		}
+		key := trimmed[:colon]
+		value := strings.TrimSpace(trimmed[colon+1:])
+		for len(sections) > 0 && sections[len(sections)-1].indent >= indent {
+			sections = sections[:len(sections)-1]
+		}
+		prefix := ""
+		for _, s := range sections {
+			if s.prefix != "" {
+				prefix += s.prefix + "_"
+			}
+		}
+
+		if value == "" {
+			// sections are only named after when they give a unit
+			s := section{indent: indent}
+			if indent > 0 || rxSection.MatchString(key) {
+				s.prefix = columnName(key)
+			}
+			sections = append(sections, s)
+			continue
+		}
+
+		if options {
+			name := columnName(key)
+			if name == "number_of_threads" {
+				name = "threads"
+			}
+			values[t.column(name)] = csvValue(value)
+			continue
+		}
+
+		name := columnName(prefix + key)
+		if m := rxPair.FindStringSubmatch(key); m != nil {
+			if v := strings.SplitN(value, "/", 2); len(v) == 2 {
+				name = columnName(prefix + m[1])
+				metric(name+"_"+columnName(m[2]), csvValue(v[0]))
+				metric(name+"_"+columnName(m[3]), csvValue(v[1]))
+				continue
+			}
+		}
+		if m := rxRate.FindStringSubmatch(value); m != nil {
+			metric(name, csvValue(m[1]))
+			metric(name+"_per_sec", csvValue(m[2]))
+			continue
+		}
+		if m := rxUnit.FindStringSubmatch(value); m != nil {
+			metric(name+"_"+columnName(m[2]), csvValue(m[1]))
+			continue
+		}
+		metric(name, csvValue(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	if len(metrics) == 0 {
+		return nil, AverError{"no sysbench results found"}
+	}
+
+	columns, rows := t.table()
+	ds, err := NewMemoryDataset(columns, rows)
+	if err != nil {
+		return nil, err
+	}
+	return WithMetrics(ds, metrics...), nil
+}
@@ -0,0 +1,91 @@
+package aver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sysbenchOutput(threads int, tps string, p95 string) string {
+	return `sysbench 1.0.20 (using system LuaJIT 2.1.0-beta3)
+
+Running the test with following options:
+Number of threads: ` + string(rune('0'+threads)) + `
+Report intermediate results every 1 second(s)
+Initializing random number generator from current time
+
+
+Initializing worker threads...
+
+Threads started!
+
+[ 1s ] thds: 4 tps: 990.61 qps: 19823.16 (r/w/o: 13878.53/3962.42/1982.21) lat (ms,95%): 5.28 err/s: 0.00 reconn/s: 0.00
+SQL statistics:
+    queries performed:
+        read:                            140000
+        write:                           40000
+        other:                           20000
+        total:                           200000
+    transactions:                        10000  (` + tps + ` per sec.)
+    queries:                             200000 (19985.40 per sec.)
+    ignored errors:                      0      (0.00 per sec.)
+    reconnects:                          0      (0.00 per sec.)
+
+General statistics:
+    total time:                          10.0059s
+    total number of events:              10000
+
+Latency (ms):
+         min:                                    2.10
+         avg:                                    4.00
+         max:                                   30.12
+         95th percentile:                        ` + p95 + `
+         sum:                                39987.52
+
+Threads fairness:
+    events (avg/stddev):           2500.0000/11.50
+    execution time (avg/stddev):   9.9969/0.00
+
+`
+}
+
+func TestSysbenchDataset(t *testing.T) {
+	ds, err := NewSysbenchDataset(strings.NewReader(
+		sysbenchOutput(4, "999.27", "5.28") + sysbenchOutput(8, "1650.02", "7.43")))
+	assert.Nil(t, err)
+
+	columns, err := ds.Schema()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"version", "threads",
+		"queries_performed_read", "queries_performed_write", "queries_performed_other",
+		"queries_performed_total", "transactions", "transactions_per_sec",
+		"queries", "queries_per_sec", "ignored_errors", "ignored_errors_per_sec",
+		"reconnects", "reconnects_per_sec", "total_time_s", "total_number_of_events",
+		"latency_ms_min", "latency_ms_avg", "latency_ms_max", "latency_ms_95th_percentile",
+		"latency_ms_sum", "events_avg", "events_stddev",
+		"execution_time_avg", "execution_time_stddev",
+	}, columns)
+
+	rows := make([][]interface{}, 0)
+	err = ds.Scan([]string{"threads", "transactions_per_sec", "total_time_s", "events_stddev"},
+		func(values []interface{}) error {
+			rows = append(rows, values)
+			return nil
+		})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{
+		{int64(4), 999.27, 10.0059, 11.5}, {int64(8), 1650.02, 10.0059, 11.5},
+	}, rows)
+
+	r, err := EvaluateDataset(
+		"expect transactions_per_sec(threads=8) > transactions_per_sec(threads=4) * 1.5",
+		ds, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+
+	_, err = NewSysbenchDataset(strings.NewReader("sysbench 1.0.20\n"))
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no sysbench results found", err.Error())
+}
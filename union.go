@@ -0,0 +1,198 @@
+package aver
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// the column that LoadDatasets adds with the file each row is read from
+const sourceFileColumn = "_source_file"
+
+// LoadDatasets reads the files that match the given patterns (see
+// filepath.Glob) into a single Dataset with the columns of all of them, plus
+// a _source_file column with the file each row is read from and those given
+// by the path template of the options (see compileTemplate). A single file
+// without a template is read as it is by LoadDataset.
+func LoadDatasets(patterns []string, opts LoadOptions) (Dataset, error) {
+	files := make([]string, 0)
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			if hasWildcards(pattern) {
+				return nil, AverError{"no files match '" + pattern + "'"}
+			}
+			// opening it reports why it can't be read
+			matches = []string{pattern}
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return nil, AverError{"no input files given"}
+	}
+	if len(patterns) == 1 && !hasWildcards(patterns[0]) && opts.PathTemplate == "" {
+		return LoadDataset(files[0], opts)
+	}
+
+	var template *regexp.Regexp
+	templateColumns := make([]string, 0)
+	if opts.PathTemplate != "" {
+		var err error
+		if template, templateColumns, err = compileTemplate(opts.PathTemplate); err != nil {
+			return nil, err
+		}
+	}
+	reserved := map[string]bool{sourceFileColumn: true}
+	for _, column := range templateColumns {
+		reserved[strings.ToLower(column)] = true
+	}
+
+	var t tableBuilder
+	metrics := make([]string, 0)
+	isMetric := make(map[string]bool)
+	addMetric := func(column string) {
+		if !isMetric[strings.ToLower(column)] {
+			isMetric[strings.ToLower(column)] = true
+			metrics = append(metrics, column)
+		}
+	}
+	runs := make([]string, 0)
+	isRun := make(map[string]bool)
+	addRun := func(column string) {
+		if !isRun[strings.ToLower(column)] {
+			isRun[strings.ToLower(column)] = true
+			runs = append(runs, column)
+		}
+	}
+	// the columns given by a template identify the file, so _source_file is
+	// a metric; otherwise it identifies the points of each file, so that they
+	// are compared with each other
+	if template != nil {
+		addMetric(sourceFileColumn)
+	}
+
+	for _, file := range files {
+		provenance := map[int]interface{}{t.column(sourceFileColumn): file}
+		if template != nil {
+			m := template.FindStringSubmatch(filepath.ToSlash(filepath.Clean(file)))
+			if m == nil {
+				return nil, AverError{
+					"file '" + file + "' doesn't match template '" + opts.PathTemplate + "'"}
+			}
+			for i, column := range templateColumns {
+				provenance[t.column(column)] = csvValue(m[i+1])
+			}
+		}
+
+		ds, err := LoadDataset(file, opts)
+		if err != nil {
+			return nil, prefixError(err, file)
+		}
+		columns, err := ds.Schema()
+		if err != nil {
+			return nil, err
+		}
+		positions := make([]int, len(columns))
+		for i, column := range columns {
+			if reserved[strings.ToLower(column)] {
+				return nil, AverError{
+					"column '" + column + "' of file '" + file + "' is taken by its path"}
+			}
+			positions[i] = t.column(column)
+		}
+		if md, ok := ds.(MetricDataset); ok {
+			for _, metric := range md.Metrics() {
+				addMetric(metric)
+			}
+		}
+		if rd, ok := ds.(RunDataset); ok {
+			for _, run := range rd.Runs() {
+				addRun(run)
+			}
+		}
+
+		err = ds.Scan(columns, func(values []interface{}) error {
+			row := make(map[int]interface{}, len(provenance)+len(values))
+			for i, value := range provenance {
+				row[i] = value
+			}
+			for i, value := range values {
+				row[positions[i]] = value
+			}
+			t.add(row)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	columns, rows := t.table()
+	ds, err := NewMemoryDataset(columns, rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(metrics) == 0 && len(runs) == 0 {
+		return ds, nil
+	}
+	if len(runs) > 0 {
+		return WithRuns(WithMetrics(ds, metrics...), runs...), nil
+	}
+	return WithMetrics(ds, metrics...), nil
+}
+
+func hasWildcards(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[\`)
+}
+
+// compiles a path template such as 'runs/{host}/{date}.csv' into a regular
+// expression that matches the paths it describes (or the end of them), with a
+// group for each of the columns it gives. A '*' matches any part of a path
+// that doesn't give a column, e.g. the name of each run in 'runs/{host}/*.csv'.
+func compileTemplate(template string) (*regexp.Regexp, []string, error) {
+	rxColumn := regexp.MustCompile(`\{([a-zA-Z_][a-zA-Z_0-9]*)\}`)
+	invalid := AverError{"invalid path template '" + template + "'"}
+
+	columns := make([]string, 0)
+	expr := ""
+	last := 0
+	for _, m := range rxColumn.FindAllStringSubmatchIndex(template, -1) {
+		literal := template[last:m[0]]
+		if strings.ContainsAny(literal, "{}") {
+			return nil, nil, invalid
+		}
+		name := template[m[2]:m[3]]
+		if strings.EqualFold(name, sourceFileColumn) {
+			return nil, nil, AverError{"column '" + name + "' is reserved"}
+		}
+		for _, column := range columns {
+			if strings.EqualFold(column, name) {
+				return nil, nil, AverError{"column '" + name + "' given twice in path template"}
+			}
+		}
+		columns = append(columns, name)
+		expr += templateLiteral(literal) + `([^/]+)`
+		last = m[1]
+	}
+	if strings.ContainsAny(template[last:], "{}") || len(columns) == 0 {
+		return nil, nil, invalid
+	}
+	expr += templateLiteral(template[last:])
+
+	rx, err := regexp.Compile(`(?:^|/)` + expr + `$`)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rx, columns, nil
+}
+
+func templateLiteral(literal string) string {
+	parts := strings.Split(literal, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return strings.Join(parts, `[^/]*`)
+}
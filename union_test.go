@@ -0,0 +1,109 @@
+package aver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDatasets(t *testing.T) {
+	path, err := ioutil.TempDir("", "aver")
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(path))
+
+	write := func(file string, data string) {
+		assert.Nil(t, os.MkdirAll(filepath.Dir(file), 0755))
+		assert.Nil(t, ioutil.WriteFile(file, []byte(data), 0644))
+	}
+	write("runs/node1/1.csv", "method,throughput\nraw,58\nceph,55.9\n")
+	write("runs/node1/2.csv", "throughput,method\n57,raw\n54.2,ceph\n")
+	write("runs/node2/1.csv", "method,Throughput\nraw,41\nceph,39.5\n")
+	write("runs/node2/2.csv", "method,throughput\nraw,42\nceph,39.1\n")
+	write("extra/1.csv", "method,throughput\nraw,58\n")
+	write("extra/2.csv", "method,latency\nraw,12\n")
+
+	scan := func(ds Dataset, columns ...string) [][]interface{} {
+		rows := make([][]interface{}, 0)
+		assert.Nil(t, ds.Scan(columns, func(values []interface{}) error {
+			rows = append(rows, values)
+			return nil
+		}))
+		return rows
+	}
+
+	ds, err := LoadDatasets([]string{"runs/node1/*.csv", "runs/node2/1.csv"}, LoadOptions{})
+	assert.Nil(t, err)
+	columns, err := ds.Schema()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"_source_file", "method", "throughput"}, columns)
+	assert.Equal(t, [][]interface{}{
+		{"runs/node1/1.csv", "raw", int64(58)},
+		{"runs/node1/1.csv", "ceph", 55.9},
+		{"runs/node1/2.csv", "raw", int64(57)},
+		{"runs/node1/2.csv", "ceph", 54.2},
+		{"runs/node2/1.csv", "raw", int64(41)},
+		{"runs/node2/1.csv", "ceph", 39.5},
+	}, scan(ds, "_source_file", "method", "throughput"))
+
+	// points of each file are compared with each other
+	r, err := EvaluateDataset(
+		"expect throughput(method='ceph') > throughput(method='raw') * 0.9", ds, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+
+	ds, err = LoadDatasets([]string{"extra/*.csv"}, LoadOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{
+		{"raw", int64(58), nil}, {"raw", nil, int64(12)},
+	}, scan(ds, "method", "throughput", "latency"))
+
+	ds, err = LoadDatasets([]string{"runs/*/*.csv"}, LoadOptions{PathTemplate: "runs/{host}/{run}.csv"})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{
+		{"node1", int64(1), "raw"}, {"node1", int64(1), "ceph"},
+		{"node1", int64(2), "raw"}, {"node1", int64(2), "ceph"},
+		{"node2", int64(1), "raw"}, {"node2", int64(1), "ceph"},
+		{"node2", int64(2), "raw"}, {"node2", int64(2), "ceph"},
+	}, scan(ds, "host", "run", "method"))
+
+	r, err = EvaluateDataset(
+		"for method='raw' expect throughput(host='node1') > throughput(host='node2')", ds, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+
+	// runs of a host are repetitions when the template leaves them out
+	ds, err = LoadDatasets([]string{"runs/*/*.csv"}, LoadOptions{PathTemplate: "runs/{host}/*.csv"})
+	assert.Nil(t, err)
+	r, err = EvaluateDataset("for each host, method expect cv(throughput) < 0.05", ds, Options{})
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+
+	// a single file is read as is
+	ds, err = LoadDatasets([]string{"runs/node2/1.csv"}, LoadOptions{})
+	assert.Nil(t, err)
+	columns, err = ds.Schema()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"method", "Throughput"}, columns)
+
+	_, err = LoadDatasets([]string{"runs/*.json"}, LoadOptions{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: no files match 'runs/*.json'", err.Error())
+
+	_, err = LoadDatasets([]string{"runs/*/*.csv"}, LoadOptions{PathTemplate: "runs/{host}.csv"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: file 'runs/node1/1.csv' doesn't match template 'runs/{host}.csv'",
+		err.Error())
+
+	_, err = LoadDatasets([]string{"runs/*/*.csv"}, LoadOptions{PathTemplate: "runs/{method}/*.csv"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: column 'method' of file 'runs/node1/1.csv' is taken by its path",
+		err.Error())
+
+	for _, template := range []string{"runs/{host/{run}.csv", "runs/*/*.csv", "{host}/{host}.csv"} {
+		_, err = LoadDatasets([]string{"runs/*/*.csv"}, LoadOptions{PathTemplate: template})
+		assert.NotNil(t, err, template)
+	}
+}
@@ -0,0 +1,135 @@
+package aver
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// GroupStatistic is the value of the statistic of a variability statement
+// (e.g. 'cv(throughput) < 0.05') for one configuration group
+type GroupStatistic struct {
+	// Group is the conjunction of predicates that selects the rows of the
+	// configuration group, e.g. "size=4 and replication=3"
+	Group string
+	// Count is the number of values in the group
+	Count     int
+	Statistic float64
+	Holds     bool
+}
+
+// evaluates a variability statement. Rows selected by the global and
+// left-side predicates are partitioned in configuration groups, one for each
+// distinct combination of values of the columns that identify a point (i.e.
+// repetitions of the same configuration end up in the same group; see
+// groupColumns). The statement holds if the
+// statistic satisfies the comparison for every group. Outliers are excluded
+// from each group before computing the statistic.
+func (v Validation) variability(ds Dataset) (
+	holds bool, stats []GroupStatistic, outliers []Outlier, err error) {
+
+	statistic, ok := statistics[v.statistic]
+	if !ok {
+		return false, nil, nil, AverError{"unknown statistic " + v.statistic}
+	}
+	isOutlier, err := v.outlierDetector()
+	if err != nil {
+		return
+	}
+	threshold, err := strconv.ParseFloat(v.right.funcName, 64)
+	if err != nil {
+		return false, nil, nil, AverError{
+			"Expecting numeric threshold for " + v.statistic + "; got " + v.right.funcName}
+	}
+	op := strings.TrimSpace(v.op)
+
+	columns, err := v.groupColumns(ds)
+	if err != nil {
+		return
+	}
+
+	points, err := selectPoints(
+		ds, columns, []string{v.left.funcName}, v.left.terms, v.globalTerms)
+	if err != nil {
+		return
+	}
+
+	keys := make([]string, 0)
+	values := make(map[string][]float64)
+	for _, p := range present(points) {
+		key := conjunction(p.terms)
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = append(values[key], p.values[0])
+	}
+	if len(keys) == 0 {
+		return false, nil, nil, AverError{"no values associated to left-side predicates"}
+	}
+
+	holds = true
+	for _, key := range keys {
+		if isOutlier != nil {
+			kept := make([]float64, 0, len(values[key]))
+			for i, outlier := range isOutlier(values[key]) {
+				if !outlier {
+					kept = append(kept, values[key][i])
+					continue
+				}
+				outliers = append(outliers, Outlier{
+					Point: and(v.left.predicates, key),
+					Value: values[key][i]})
+			}
+			values[key] = kept
+		}
+		if len(values[key]) < 2 {
+			return false, nil, nil, AverError{
+				"variability requires repeated measurements but group '" + key +
+					"' has a single value"}
+		}
+		s := GroupStatistic{key, len(values[key]), statistic(values[key]), false}
+		s.Holds = compare(s.Statistic, op, threshold)
+		holds = holds && s.Holds
+		stats = append(stats, s)
+	}
+	return
+}
+
+// obtains the columns that identify the configuration groups of a variability
+// statement. For datasets that tell their metrics, they are the join columns,
+// which leave out the measured column and the metrics, without the columns
+// that number the runs of a configuration (see RunDataset). Any other column could
+// be a measurement as well, so datasets that don't, such as tables of a
+// database, are grouped by the columns given in 'for each', which the
+// statement has to name.
+func (v Validation) groupColumns(ds Dataset) ([]string, error) {
+	if _, ok := ds.(MetricDataset); ok {
+		columns, err := v.joinColumns(ds)
+		return withoutRuns(ds, columns), err
+	}
+	if len(v.groupBy) == 0 {
+		return nil, AverError{"variability statements on datasets that don't tell their " +
+			"metrics have to give the columns of configuration groups with 'for each'"}
+	}
+	return v.groupBy, nil
+}
+
+// returns the group whose statistic is the furthest from satisfying the
+// comparison
+func worstGroup(stats []GroupStatistic, op string, threshold float64) (worst GroupStatistic) {
+	score := func(s GroupStatistic) float64 {
+		switch strings.TrimSpace(op) {
+		case "<", "<=":
+			return s.Statistic
+		case ">", ">=":
+			return -s.Statistic
+		}
+		return math.Abs(s.Statistic - threshold)
+	}
+	for i, s := range stats {
+		if i == 0 || score(s) > score(worst) {
+			worst = s
+		}
+	}
+	return
+}
@@ -0,0 +1,181 @@
+package aver
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadRunsTable(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(`
+		CREATE TABLE runs (
+			size INT,
+			method VARCHAR(255),
+			throughput FLOAT
+		)
+	`)
+	assert.Nil(t, err)
+
+	for _, row := range []string{
+		"1, 'a', 100", "1, 'a', 101", "1, 'a', 99",
+		"2, 'a', 200", "2, 'a', 202", "2, 'a', 198",
+		"1, 'b', 100", "1, 'b', 150", "1, 'b', 50",
+		"2, 'b', 200", "2, 'b', 201", "2, 'b', 199",
+	} {
+		_, err = db.Exec("INSERT INTO runs VALUES(" + row + ")")
+		assert.Nil(t, err)
+	}
+}
+
+func TestVariability(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadRunsTable(t, db)
+
+	r, err := Evaluate("for each size expect cv(throughput(method='a')) < 0.05", db, "runs")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 2, len(r.Statistics))
+	assert.Equal(t, "size=1", r.Statistics[0].Group)
+	assert.Equal(t, 3, r.Statistics[0].Count)
+	assert.InDelta(t, 0.01, r.Statistics[0].Statistic, 0.0001)
+	assert.Equal(t, "size=1", r.Worst.Group)
+
+	r, err = Evaluate("for each size expect stddev(throughput(method='b')) < 2", db, "runs")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, "size=1", r.Worst.Group)
+	assert.InDelta(t, 50, r.Worst.Statistic, 0.0001)
+	assert.False(t, r.Worst.Holds)
+	assert.True(t, r.Statistics[1].Holds)
+
+	r, err = Evaluate(`
+	for size = 2
+	for each size, method
+	expect
+	  iqr(throughput) <= 2
+	`, db, "runs")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, []string{"size=2 and method='a'", "size=2 and method='b'"},
+		[]string{r.Statistics[0].Group, r.Statistics[1].Group})
+
+	holds, err := Holds(`
+	for each method
+	expect
+	  mad(throughput) < $max
+	`, db, "runs", Params{"max": 10})
+
+	assert.Nil(t, err)
+	assert.False(t, holds)
+}
+
+func TestVariabilitySingleValue(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	loadTestTable(t, db)
+
+	_, err := Holds(
+		"for each size, replication expect cv(throughput(method='raw')) < 0.05", db, "metrics")
+
+	assert.NotNil(t, err)
+	assert.Equal(t,
+		"aver: for each size=1 and replication=3: variability requires repeated "+
+			"measurements but group 'size=1 and replication=3' has a single value", err.Error())
+
+	// any column of a table could be a measurement, so groups are given by the
+	// statement
+	_, err = Holds("expect cv(throughput(method='raw')) < 0.05", db, "metrics")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "aver: variability statements on datasets that don't tell their "+
+		"metrics have to give the columns of configuration groups with 'for each'", err.Error())
+}
+
+func TestVariabilityTwoMetrics(t *testing.T) {
+	db := openDB(t, ":memory:")
+	defer db.Close()
+
+	_, err := db.Exec(`
+		CREATE TABLE runs (
+			size INT,
+			method VARCHAR(255),
+			throughput FLOAT,
+			latency FLOAT
+		)
+	`)
+	assert.Nil(t, err)
+	for _, row := range []string{
+		"1, 'a', 100, 5.1", "1, 'a', 101, 5.3", "1, 'a', 99, 5.2",
+		"2, 'a', 200, 3.1", "2, 'a', 202, 2.9", "2, 'a', 198, 3.4",
+	} {
+		_, err = db.Exec("INSERT INTO runs VALUES(" + row + ")")
+		assert.Nil(t, err)
+	}
+
+	// the other metric doesn't tell apart the groups
+	r, err := Evaluate("for each size expect cv(throughput(method='a')) < 0.05", db, "runs")
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, []string{"size=1", "size=2"},
+		[]string{r.Statistics[0].Group, r.Statistics[1].Group})
+
+	r, err = Evaluate("for each size expect stddev(latency) < 0.1", db, "runs")
+
+	assert.Nil(t, err)
+	assert.False(t, r.Holds)
+	assert.Equal(t, "size=2", r.Worst.Group)
+
+	// nor do metrics of datasets that tell them
+	ds, err := NewMemoryDataset([]string{"size", "throughput", "latency"}, [][]interface{}{
+		{1, 100, 5}, {1, 101, 6}, {2, 200, 3}, {2, 202, 4},
+	})
+	assert.Nil(t, err)
+
+	r, err = EvaluateDataset("expect cv(throughput) < 0.05",
+		WithMetrics(ds, "throughput", "latency"), Options{})
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, 2, len(r.Statistics))
+
+	// integer-valued metrics don't either
+	ds, err = NewMemoryDataset([]string{"size", "throughput", "latency"}, [][]interface{}{
+		{1, 100, 51}, {1, 101, 52}, {1, 99, 50}, {2, 200, 31}, {2, 202, 30}, {2, 198, 33},
+	})
+	assert.Nil(t, err)
+	for _, d := range []Dataset{ds, WithMetrics(ds, "throughput", "latency")} {
+		r, err = EvaluateDataset("for each size expect cv(throughput) < 0.05", d, Options{})
+
+		assert.Nil(t, err)
+		assert.True(t, r.Holds)
+		assert.Equal(t, []string{"size=1", "size=2"},
+			[]string{r.Statistics[0].Group, r.Statistics[1].Group})
+	}
+
+	// nor do parameters with fractional values fail to
+	ds, err = NewMemoryDataset([]string{"ratio", "throughput"}, [][]interface{}{
+		{0.5, 100}, {0.5, 101}, {0.5, 99}, {1.5, 300}, {1.5, 303}, {1.5, 297},
+	})
+	assert.Nil(t, err)
+	for _, d := range []Dataset{ds, WithMetrics(ds, "throughput")} {
+		r, err = EvaluateDataset("for each ratio expect cv(throughput) < 0.05", d, Options{})
+
+		assert.Nil(t, err)
+		assert.True(t, r.Holds)
+		assert.Equal(t, 2, len(r.Statistics))
+	}
+	r, err = EvaluateDataset("expect cv(throughput) < 0.05", WithMetrics(ds, "throughput"), Options{})
+
+	assert.Nil(t, err)
+	assert.True(t, r.Holds)
+	assert.Equal(t, []string{"ratio=0.5", "ratio=1.5"},
+		[]string{r.Statistics[0].Group, r.Statistics[1].Group})
+}